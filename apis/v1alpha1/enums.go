@@ -77,14 +77,14 @@ const (
 	ResourceRegionScopeFilter_REGIONAL ResourceRegionScopeFilter = "REGIONAL"
 )
 
-type ResourceShareAssociationStatus string
+type ResourceShareAssociationStatus_SDK string
 
 const (
-	ResourceShareAssociationStatus_ASSOCIATED     ResourceShareAssociationStatus = "ASSOCIATED"
-	ResourceShareAssociationStatus_ASSOCIATING    ResourceShareAssociationStatus = "ASSOCIATING"
-	ResourceShareAssociationStatus_DISASSOCIATED  ResourceShareAssociationStatus = "DISASSOCIATED"
-	ResourceShareAssociationStatus_DISASSOCIATING ResourceShareAssociationStatus = "DISASSOCIATING"
-	ResourceShareAssociationStatus_FAILED         ResourceShareAssociationStatus = "FAILED"
+	ResourceShareAssociationStatus_SDK_ASSOCIATED     ResourceShareAssociationStatus_SDK = "ASSOCIATED"
+	ResourceShareAssociationStatus_SDK_ASSOCIATING    ResourceShareAssociationStatus_SDK = "ASSOCIATING"
+	ResourceShareAssociationStatus_SDK_DISASSOCIATED  ResourceShareAssociationStatus_SDK = "DISASSOCIATED"
+	ResourceShareAssociationStatus_SDK_DISASSOCIATING ResourceShareAssociationStatus_SDK = "DISASSOCIATING"
+	ResourceShareAssociationStatus_SDK_FAILED         ResourceShareAssociationStatus_SDK = "FAILED"
 )
 
 type ResourceShareAssociationType string
@@ -102,13 +102,13 @@ const (
 	ResourceShareFeatureSet_STANDARD              ResourceShareFeatureSet = "STANDARD"
 )
 
-type ResourceShareInvitationStatus string
+type ResourceShareInvitationStatus_SDK string
 
 const (
-	ResourceShareInvitationStatus_ACCEPTED ResourceShareInvitationStatus = "ACCEPTED"
-	ResourceShareInvitationStatus_EXPIRED  ResourceShareInvitationStatus = "EXPIRED"
-	ResourceShareInvitationStatus_PENDING  ResourceShareInvitationStatus = "PENDING"
-	ResourceShareInvitationStatus_REJECTED ResourceShareInvitationStatus = "REJECTED"
+	ResourceShareInvitationStatus_SDK_ACCEPTED ResourceShareInvitationStatus_SDK = "ACCEPTED"
+	ResourceShareInvitationStatus_SDK_EXPIRED  ResourceShareInvitationStatus_SDK = "EXPIRED"
+	ResourceShareInvitationStatus_SDK_PENDING  ResourceShareInvitationStatus_SDK = "PENDING"
+	ResourceShareInvitationStatus_SDK_REJECTED ResourceShareInvitationStatus_SDK = "REJECTED"
 )
 
 type ResourceShareStatus_SDK string
@@ -0,0 +1,86 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InvitationAcceptancePolicySpec defines the desired state of InvitationAcceptancePolicy.
+//
+// Describes which pending resource share invitations received by the
+// controller's AWS account are responded to automatically.
+type InvitationAcceptancePolicySpec struct {
+
+	// Specifies how to respond to invitations that match the policy. Valid values
+	// are ACCEPT and REJECT. Invitations that don't match the policy are left
+	// untouched. When several policies match an invitation, a policy that rejects
+	// it takes precedence over a policy that accepts it, and among policies with
+	// the same action the one whose name sorts first responds.
+	// +kubebuilder:validation:Enum=ACCEPT;REJECT
+	// +kubebuilder:default=ACCEPT
+	Action *string `json:"action,omitempty"`
+	// Specifies how often, in seconds, RAM is polled for pending invitations.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:default=300
+	PollIntervalSeconds *int64 `json:"pollIntervalSeconds,omitempty"`
+	// Specifies a list of tag key and value pairs that the resource share must
+	// have for its invitation to match the policy. Invitations for resource shares
+	// whose tags can't be read are not matched.
+	RequiredTags []*Tag `json:"requiredTags,omitempty"`
+	// Specifies a list of patterns, in the syntax of Go's path.Match, that the
+	// name of the resource share must match. If empty, invitations for resource
+	// shares with any name match the policy.
+	ResourceShareNamePatterns []*string `json:"resourceShareNamePatterns,omitempty"`
+	// Specifies the IDs of the Amazon Web Services accounts that invitations must
+	// be sent by to match the policy.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	TrustedSenderAccountIDs []*string `json:"trustedSenderAccountIDs"`
+}
+
+// InvitationAcceptancePolicyStatus defines the observed state of InvitationAcceptancePolicy
+type InvitationAcceptancePolicyStatus struct {
+	// Contains a collection of `ackv1alpha1.Condition` objects that describe
+	// the outcome of the last poll of RAM for pending invitations
+	// +kubebuilder:validation:Optional
+	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The date and time when RAM was last polled for pending invitations.
+	// +kubebuilder:validation:Optional
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
+}
+
+// InvitationAcceptancePolicy is the Schema for the InvitationAcceptancePolicies API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type InvitationAcceptancePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              InvitationAcceptancePolicySpec   `json:"spec,omitempty"`
+	Status            InvitationAcceptancePolicyStatus `json:"status,omitempty"`
+}
+
+// InvitationAcceptancePolicyList contains a list of InvitationAcceptancePolicy
+// +kubebuilder:object:root=true
+type InvitationAcceptancePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InvitationAcceptancePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InvitationAcceptancePolicy{}, &InvitationAcceptancePolicyList{})
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OrganizationSharingSpec defines the desired state of OrganizationSharing.
+//
+// Enables sharing resources with the organization of the controller's AWS
+// account, which must be the management account of the organization.
+// Sharing is left enabled when the resource is deleted. ResourceShares
+// sharing with the organization report its state in their
+// OrganizationSharingEnabled condition, which they only refresh the next
+// time they are reconciled.
+type OrganizationSharingSpec struct {
+
+	// Specifies how often, in seconds, sharing with the organization is
+	// enabled again and its state refreshed.
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=3600
+	ResyncPeriodSeconds *int64 `json:"resyncPeriodSeconds,omitempty"`
+}
+
+// OrganizationSharingStatus defines the observed state of OrganizationSharing
+type OrganizationSharingStatus struct {
+	// Contains a collection of `ackv1alpha1.Condition` objects that describe
+	// the outcome of the last attempt to enable sharing with the organization
+	// +kubebuilder:validation:Optional
+	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// Indicates whether sharing with the organization is enabled.
+	// +kubebuilder:validation:Optional
+	SharingEnabled *bool `json:"sharingEnabled,omitempty"`
+	// The date and time when sharing with the organization was last enabled.
+	// +kubebuilder:validation:Optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// OrganizationSharing is the Schema for the OrganizationSharings API
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ENABLED",type=boolean,JSONPath=`.status.sharingEnabled`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="the OrganizationSharing resource must be named default"
+type OrganizationSharing struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              OrganizationSharingSpec   `json:"spec,omitempty"`
+	Status            OrganizationSharingStatus `json:"status,omitempty"`
+}
+
+// OrganizationSharingList contains a list of OrganizationSharing
+// +kubebuilder:object:root=true
+type OrganizationSharingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OrganizationSharing `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OrganizationSharing{}, &OrganizationSharingList{})
+}
@@ -29,6 +29,15 @@ type PermissionSpec struct {
 	// Regex Pattern: `^[\w.-]*$`
 	// +kubebuilder:validation:Required
 	Name *string `json:"name"`
+	// Specifies the version of the permission to set as its default version. Use
+	// it to roll back to an earlier version of the policy template without recreating
+	// the permission. When not set, the most recent version is the default version.
+	PinnedVersion *int64 `json:"pinnedVersion,omitempty"`
+	// Specifies the statements of the policy template in a structured form, as
+	// an alternative to writing PolicyTemplate as a JSON string. The controller
+	// renders them to the policy template it sends to RAM. Exactly one of PolicyTemplate
+	// and PolicyStatements must be specified.
+	PolicyStatements []*PermissionPolicyStatement `json:"policyStatements,omitempty"`
 	// A string in JSON format string that contains the following elements of a
 	// resource-based policy:
 	//
@@ -52,8 +61,16 @@ type PermissionSpec struct {
 	// on each resource shared using this managed permission. The Resource comes
 	// from the ARN of the specific resource that you are sharing. The Principal
 	// comes from the list of identities added to the resource share.
-	// +kubebuilder:validation:Required
-	PolicyTemplate *string `json:"policyTemplate"`
+	//
+	// Exactly one of PolicyTemplate and PolicyStatements must be specified.
+	PolicyTemplate *string `json:"policyTemplate,omitempty"`
+	// Specifies whether the resource shares that use the previous default version
+	// of the permission are moved to the new version with ReplacePermissionAssociations
+	// when a change to the policy template creates a new version. The progress
+	// of the replacement is reported in Status.ReplacePermissionAssociationsWork.
+	// A version that resource shares are still being moved away from isn't deleted
+	// until the next change to the policy template.
+	ReplacePermissionAssociations *bool `json:"replacePermissionAssociations,omitempty"`
 	// Specifies the name of the resource type that this customer managed permission
 	// applies to.
 	//
@@ -62,6 +79,14 @@ type PermissionSpec struct {
 	// for this parameter, query the ListResourceTypes operation.
 	// +kubebuilder:validation:Required
 	ResourceType *string `json:"resourceType"`
+	// Specifies how many versions of the permission are kept when a change to
+	// the policy template creates a new version. The oldest versions beyond this
+	// number are deleted, except for the default version. RAM allows a permission
+	// to have up to five versions. The default value is 1, which keeps only the
+	// most recent version.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	RetainedVersions *int64 `json:"retainedVersions,omitempty"`
 	// Specifies a list of one or more tag key and value pairs to attach to the
 	// permission.
 	Tags []*Tag `json:"tags,omitempty"`
@@ -80,6 +105,11 @@ type PermissionStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// A counter the controller mixes into the client tokens of its RAM calls.
+	// It is bumped once the calls succeeded, so that a call made again after
+	// an out-of-band change isn't answered with the result of the earlier one.
+	// +kubebuilder:validation:Optional
+	ClientTokenNonce *int64 `json:"clientTokenNonce,omitempty"`
 	// The date and time when the permission was created.
 	// +kubebuilder:validation:Optional
 	CreationTime *metav1.Time `json:"creationTime,omitempty"`
@@ -116,6 +146,11 @@ type PermissionStatus struct {
 	// The date and time when the permission was last updated.
 	// +kubebuilder:validation:Optional
 	LastUpdatedTime *metav1.Time `json:"lastUpdatedTime,omitempty"`
+	// The policy template of the most recent version of the permission, as
+	// returned by RAM. Permissions described by policyStatements are compared
+	// against it, since their policyTemplate is left unset.
+	// +kubebuilder:validation:Optional
+	ObservedPolicyTemplate *string `json:"observedPolicyTemplate,omitempty"`
 	// The type of managed permission. This can be one of the following values:
 	//
 	//    * AWS_MANAGED – Amazon Web Services created and manages this managed
@@ -127,12 +162,19 @@ type PermissionStatus struct {
 	//    and create new versions that have different permissions.
 	// +kubebuilder:validation:Optional
 	PermissionType *string `json:"permissionType,omitempty"`
+	// The most recent work that moves the resource shares using the previous
+	// default version of the permission to its new default version.
+	// +kubebuilder:validation:Optional
+	ReplacePermissionAssociationsWork *ReplacePermissionAssociationsWork `json:"replacePermissionAssociationsWork,omitempty"`
 	// The current status of the permission.
 	// +kubebuilder:validation:Optional
 	Status *string `json:"status,omitempty"`
 	// The version of the permission associated with this resource share.
 	// +kubebuilder:validation:Optional
 	Version *string `json:"version,omitempty"`
+	// The versions of the permission, ordered from the oldest to the most recent.
+	// +kubebuilder:validation:Optional
+	Versions []*ResourceSharePermissionSummary `json:"versions,omitempty"`
 }
 
 // Permission is the Schema for the Permissions API
@@ -0,0 +1,39 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+// PermissionPolicyStatement is a statement of the policy template of a
+// customer managed permission, in a structured form that the controller
+// renders to JSON.
+type PermissionPolicyStatement struct {
+	// Specifies the actions the statement allows, for example ec2:DescribeSubnets.
+	// Wildcards are supported in the action name, for example ec2:Describe*.
+	Actions []*string `json:"actions,omitempty"`
+	// Specifies the conditions under which the statement is in effect.
+	Conditions []*PermissionPolicyCondition `json:"conditions,omitempty"`
+	// Specifies the effect of the statement. RAM only supports Allow, which
+	// is the default value.
+	Effect *string `json:"effect,omitempty"`
+}
+
+// PermissionPolicyCondition is a condition of a PermissionPolicyStatement.
+type PermissionPolicyCondition struct {
+	// Specifies the condition key, for example aws:PrincipalOrgID.
+	Key *string `json:"key,omitempty"`
+	// Specifies the condition operator, for example StringEquals or
+	// ForAnyValue:StringLike.
+	Operator *string `json:"operator,omitempty"`
+	// Specifies the values the condition key is compared with.
+	Values []*string `json:"values,omitempty"`
+}
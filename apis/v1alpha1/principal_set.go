@@ -0,0 +1,50 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrincipalSetSpec defines the desired state of PrincipalSet.
+//
+// Describes a list of principals that resource shares reference instead of
+// listing the principals themselves.
+type PrincipalSetSpec struct {
+
+	// Specifies the principals of the set, in any of the forms accepted by the
+	// principals of a ResourceShare.
+	// +kubebuilder:validation:Required
+	Principals []*string `json:"principals"`
+}
+
+// PrincipalSet is the Schema for the PrincipalSets API
+// +kubebuilder:object:root=true
+type PrincipalSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PrincipalSetSpec `json:"spec,omitempty"`
+}
+
+// PrincipalSetList contains a list of PrincipalSet
+// +kubebuilder:object:root=true
+type PrincipalSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PrincipalSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PrincipalSet{}, &PrincipalSetList{})
+}
@@ -0,0 +1,68 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceReferenceWrapper wraps a reference to an ACK resource of any
+// service.
+type ResourceReferenceWrapper struct {
+	From *ResourceReference `json:"from,omitempty"`
+}
+
+// ResourceReference is a reference to an ACK resource of any service, whose
+// Status.ACKResourceMetadata.ARN is used once the resource is synced.
+type ResourceReference struct {
+	// Specifies the API group of the referenced resource, for example
+	// ec2.services.k8s.aws.
+	// The controller can read the resources of the ec2, networkfirewall,
+	// organizations, rds and route53resolver ACK services. Other API groups
+	// require granting the controller's ClusterRole get, list and watch on
+	// their resources.
+	Group *string `json:"group,omitempty"`
+	// Specifies the kind of the referenced resource, for example Subnet.
+	Kind *string `json:"kind,omitempty"`
+	// Specifies the name of the referenced resource.
+	Name *string `json:"name,omitempty"`
+	// Specifies the namespace of the referenced resource. Defaults to the
+	// namespace of the referencing resource.
+	Namespace *string `json:"namespace,omitempty"`
+	// Specifies the API version of the referenced resource. Defaults to
+	// v1alpha1.
+	Version *string `json:"version,omitempty"`
+}
+
+// ResourceSelector selects the ACK resources of a kind by label.
+type ResourceSelector struct {
+	// Specifies the API group of the selected resources, for example
+	// ec2.services.k8s.aws.
+	// The controller can read the resources of the ec2, networkfirewall,
+	// organizations, rds and route53resolver ACK services. Other API groups
+	// require granting the controller's ClusterRole get, list and watch on
+	// their resources.
+	Group *string `json:"group,omitempty"`
+	// Specifies the kind of the selected resources, for example Subnet.
+	Kind *string `json:"kind,omitempty"`
+	// Specifies the label selector the selected resources match. A resource
+	// selector without a label selector selects every resource of the kind in
+	// the namespace.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// Specifies the namespace of the selected resources. Defaults to the namespace
+	// of the selecting resource.
+	Namespace *string `json:"namespace,omitempty"`
+	// Specifies the API version of the selected resources. Defaults to v1alpha1.
+	Version *string `json:"version,omitempty"`
+}
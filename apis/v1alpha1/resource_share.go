@@ -31,6 +31,13 @@ type ResourceShareSpec struct {
 	// A value of false only has meaning if your account is a member of an Amazon
 	// Web Services Organization. The default value is true.
 	AllowExternalPrincipals *bool `json:"allowExternalPrincipals,omitempty"`
+	// Specifies how the controller manages the principals, resources and sources
+	// associated with the resource share. With Authoritative, the default, the
+	// controller disassociates whatever isn't listed in the resource share. With
+	// Additive, it only disassociates what it associated itself, as tracked in
+	// Status.ManagedPrincipals, Status.ManagedResourceARNs and Status.ManagedSources,
+	// and leaves the associations made by other tools alone.
+	AssociationMode *string `json:"associationMode,omitempty"`
 	// Specifies the name of the resource share.
 	// +kubebuilder:validation:Required
 	Name *string `json:"name"`
@@ -61,12 +68,59 @@ type ResourceShareSpec struct {
 	// Not all resource types can be shared with IAM roles and users. For more information,
 	// see Sharing with IAM roles and users (https://docs.aws.amazon.com/ram/latest/userguide/permissions.html#permissions-rbp-supported-resource-types)
 	// in the Resource Access Manager User Guide.
+	//
+	// When principals is unset, the controller leaves the principals of the resource
+	// share alone, so that ResourceShareAssociation resources can manage them. When
+	// it is set, even to an empty list, the principals it doesn't list are disassociated,
+	// except the ones associated by ResourceShareAssociation resources and, in the
+	// Additive association mode, the ones the controller didn't associate.
 	Principals []*string `json:"principals,omitempty"`
+	// Specifies ACK resources of any service, typically Organizations accounts
+	// and organizational units, that are associated with the resource share as
+	// principals in place of Principals. An account is associated by its ID, anything
+	// else by its ARN.
+	PrincipalRefs []*ResourceReferenceWrapper `json:"principalRefs,omitempty"`
+	// Specifies PrincipalSet resources whose principals are associated with the
+	// resource share in place of Principals. The resource share follows the changes
+	// to the principal sets.
+	PrincipalSetRefs []*ackv1alpha1.AWSResourceReferenceWrapper `json:"principalSetRefs,omitempty"`
+	// Specifies whether a resource share adopted with the CREATED_FROM_POLICY feature
+	// set, which RAM creates when a resource-based policy is attached to a resource
+	// and doesn't let you modify, is promoted to a STANDARD resource share. Its
+	// CREATED_FROM_POLICY permissions are promoted to customer managed permissions
+	// first. The progress of the promotion is reported in Status.FeatureSet.
+	PromoteCreatedFromPolicy *bool `json:"promoteCreatedFromPolicy,omitempty"`
 	// Specifies a list of one or more ARNs of the resources to associate with the
 	// resource share.
+	//
+	// When resourceARNs is unset, the controller leaves the resources of the resource
+	// share alone, so that ResourceShareAssociation resources can manage them. When
+	// it is set, even to an empty list, the resources it doesn't list are disassociated,
+	// except the ones associated by ResourceShareAssociation resources and, in the
+	// Additive association mode, the ones the controller didn't associate.
 	ResourceARNs []*string `json:"resourceARNs,omitempty"`
+	// Specifies ACK resources of any service, for example EC2 subnets, whose ARNs
+	// are associated with the resource share in place of ResourceARNs. The controller
+	// must be allowed to read the referenced kinds.
+	ResourceRefs []*ResourceReferenceWrapper `json:"resourceRefs,omitempty"`
+	// Specifies label selectors of ACK resources of any service whose ARNs are
+	// associated with the resource share in place of ResourceARNs, for example
+	// every EC2 subnet of a namespace labeled share=workloads. The controller
+	// watches the selected kinds, so resources are associated and disassociated
+	// as they start or stop matching a selector.
+	ResourceSelectors []*ResourceSelector `json:"resourceSelectors,omitempty"`
+	// Set by the controller to a digest of the resources selected by
+	// ResourceSelectors and of the generations of the principal sets referenced
+	// by PrincipalSetRefs. The controller updates it when either changes, which
+	// makes it reconcile the resource share again. Don't set it yourself.
+	SelectionRevision *string `json:"selectionRevision,omitempty"`
 	// Specifies from which source accounts the service principal has access to
 	// the resources in this resource share.
+	//
+	// When sources is unset, the controller leaves the sources of the resource share
+	// alone. When it is set, even to an empty list, the sources it doesn't list are
+	// disassociated, except, in the Additive association mode, the ones the controller
+	// didn't associate.
 	Sources []*string `json:"sources,omitempty"`
 	// A list of one or more tag key and value pairs. The tag key must be present
 	// and not be an empty string. The tag value must be present but can be an empty
@@ -87,6 +141,11 @@ type ResourceShareStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// A counter the controller mixes into the client tokens of its RAM calls.
+	// It is bumped once the calls succeeded, so that a call made again after
+	// an out-of-band change isn't answered with the result of the earlier one.
+	// +kubebuilder:validation:Optional
+	ClientTokenNonce *int64 `json:"clientTokenNonce,omitempty"`
 	// The date and time when the resource share was created.
 	// +kubebuilder:validation:Optional
 	CreationTime *metav1.Time `json:"creationTime,omitempty"`
@@ -115,9 +174,42 @@ type ResourceShareStatus struct {
 	// The date and time when the resource share was last updated.
 	// +kubebuilder:validation:Optional
 	LastUpdatedTime *metav1.Time `json:"lastUpdatedTime,omitempty"`
+	// The principals the controller associated with the resource share, when
+	// Spec.AssociationMode is Additive.
+	// +kubebuilder:validation:Optional
+	ManagedPrincipals []*string `json:"managedPrincipals,omitempty"`
+	// The resources the controller associated with the resource share, when
+	// Spec.AssociationMode is Additive.
+	// +kubebuilder:validation:Optional
+	ManagedResourceARNs []*string `json:"managedResourceARNs,omitempty"`
+	// The sources the controller associated with the resource share, when
+	// Spec.AssociationMode is Additive.
+	// +kubebuilder:validation:Optional
+	ManagedSources []*string `json:"managedSources,omitempty"`
 	// The ID of the Amazon Web Services account that owns the resource share.
 	// +kubebuilder:validation:Optional
 	OwningAccountID *string `json:"owningAccountID,omitempty"`
+	// The principals associated with the resource share, along with the status
+	// of their association.
+	// +kubebuilder:validation:Optional
+	PrincipalAssociations []*ResourceShareAssociation_SDK `json:"principalAssociations,omitempty"`
+	// The principals associated with the resource share by ResourceShareAssociation
+	// resources, which the resource share never disassociates.
+	// +kubebuilder:validation:Optional
+	PrincipalsFromAssociations []*string `json:"principalsFromAssociations,omitempty"`
+	// The ARNs of the customer managed permissions the controller promoted the
+	// CREATED_FROM_POLICY permissions of the resource share to, when
+	// Spec.PromoteCreatedFromPolicy is set.
+	// +kubebuilder:validation:Optional
+	PromotedPermissionARNs []*string `json:"promotedPermissionARNs,omitempty"`
+	// The resources associated with the resource share by ResourceShareAssociation
+	// resources, which the resource share never disassociates.
+	// +kubebuilder:validation:Optional
+	ResourceARNsFromAssociations []*string `json:"resourceARNsFromAssociations,omitempty"`
+	// The resources associated with the resource share, along with the status
+	// of their association.
+	// +kubebuilder:validation:Optional
+	ResourceAssociations []*ResourceShareAssociation_SDK `json:"resourceAssociations,omitempty"`
 	// The current status of the resource share.
 	// +kubebuilder:validation:Optional
 	Status *string `json:"status,omitempty"`
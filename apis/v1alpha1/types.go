@@ -58,6 +58,7 @@ type ReplacePermissionAssociationsWork struct {
 	FromPermissionVersion *string      `json:"fromPermissionVersion,omitempty"`
 	ID                    *string      `json:"id,omitempty"`
 	LastUpdatedTime       *metav1.Time `json:"lastUpdatedTime,omitempty"`
+	Status                *string      `json:"status,omitempty"`
 	StatusMessage         *string      `json:"statusMessage,omitempty"`
 	ToPermissionARN       *string      `json:"toPermissionARN,omitempty"`
 	ToPermissionVersion   *string      `json:"toPermissionVersion,omitempty"`
@@ -76,19 +77,21 @@ type Resource struct {
 
 // Describes an association between a resource share and either a principal
 // or a resource.
-type ResourceShareAssociation struct {
+type ResourceShareAssociation_SDK struct {
 	AssociatedEntity  *string      `json:"associatedEntity,omitempty"`
+	AssociationType   *string      `json:"associationType,omitempty"`
 	CreationTime      *metav1.Time `json:"creationTime,omitempty"`
 	External          *bool        `json:"external,omitempty"`
 	LastUpdatedTime   *metav1.Time `json:"lastUpdatedTime,omitempty"`
 	ResourceShareARN  *string      `json:"resourceShareARN,omitempty"`
 	ResourceShareName *string      `json:"resourceShareName,omitempty"`
+	Status            *string      `json:"status,omitempty"`
 	StatusMessage     *string      `json:"statusMessage,omitempty"`
 }
 
 // Describes an invitation for an Amazon Web Services account to join a resource
 // share.
-type ResourceShareInvitation struct {
+type ResourceShareInvitation_SDK struct {
 	InvitationTimestamp        *metav1.Time `json:"invitationTimestamp,omitempty"`
 	ReceiverAccountID          *string      `json:"receiverAccountID,omitempty"`
 	ReceiverARN                *string      `json:"receiverARN,omitempty"`
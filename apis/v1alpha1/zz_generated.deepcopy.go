@@ -21,6 +21,7 @@ package v1alpha1
 
 import (
 	corev1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -79,7 +80,7 @@ func (in *AssociatedPermission) DeepCopy() *AssociatedPermission {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Permission) DeepCopyInto(out *Permission) {
+func (in *InvitationAcceptancePolicy) DeepCopyInto(out *InvitationAcceptancePolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -87,18 +88,18 @@ func (in *Permission) DeepCopyInto(out *Permission) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permission.
-func (in *Permission) DeepCopy() *Permission {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvitationAcceptancePolicy.
+func (in *InvitationAcceptancePolicy) DeepCopy() *InvitationAcceptancePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Permission)
+	out := new(InvitationAcceptancePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Permission) DeepCopyObject() runtime.Object {
+func (in *InvitationAcceptancePolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -106,31 +107,31 @@ func (in *Permission) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionList) DeepCopyInto(out *PermissionList) {
+func (in *InvitationAcceptancePolicyList) DeepCopyInto(out *InvitationAcceptancePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Permission, len(*in))
+		*out = make([]InvitationAcceptancePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionList.
-func (in *PermissionList) DeepCopy() *PermissionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvitationAcceptancePolicyList.
+func (in *InvitationAcceptancePolicyList) DeepCopy() *InvitationAcceptancePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionList)
+	out := new(InvitationAcceptancePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PermissionList) DeepCopyObject() runtime.Object {
+func (in *InvitationAcceptancePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -138,25 +139,20 @@ func (in *PermissionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionSpec) DeepCopyInto(out *PermissionSpec) {
+func (in *InvitationAcceptancePolicySpec) DeepCopyInto(out *InvitationAcceptancePolicySpec) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
-		**out = **in
-	}
-	if in.PolicyTemplate != nil {
-		in, out := &in.PolicyTemplate, &out.PolicyTemplate
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
 		*out = new(string)
 		**out = **in
 	}
-	if in.ResourceType != nil {
-		in, out := &in.ResourceType, &out.ResourceType
-		*out = new(string)
+	if in.PollIntervalSeconds != nil {
+		in, out := &in.PollIntervalSeconds, &out.PollIntervalSeconds
+		*out = new(int64)
 		**out = **in
 	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
+	if in.RequiredTags != nil {
+		in, out := &in.RequiredTags, &out.RequiredTags
 		*out = make([]*Tag, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
@@ -166,26 +162,43 @@ func (in *PermissionSpec) DeepCopyInto(out *PermissionSpec) {
 			}
 		}
 	}
+	if in.ResourceShareNamePatterns != nil {
+		in, out := &in.ResourceShareNamePatterns, &out.ResourceShareNamePatterns
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.TrustedSenderAccountIDs != nil {
+		in, out := &in.TrustedSenderAccountIDs, &out.TrustedSenderAccountIDs
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSpec.
-func (in *PermissionSpec) DeepCopy() *PermissionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvitationAcceptancePolicySpec.
+func (in *InvitationAcceptancePolicySpec) DeepCopy() *InvitationAcceptancePolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionSpec)
+	out := new(InvitationAcceptancePolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PermissionStatus) DeepCopyInto(out *PermissionStatus) {
+func (in *InvitationAcceptancePolicyStatus) DeepCopyInto(out *InvitationAcceptancePolicyStatus) {
 	*out = *in
-	if in.ACKResourceMetadata != nil {
-		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
-		*out = new(corev1alpha1.ResourceMetadata)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]*corev1alpha1.Condition, len(*in))
@@ -197,152 +210,963 @@ func (in *PermissionStatus) DeepCopyInto(out *PermissionStatus) {
 			}
 		}
 	}
-	if in.CreationTime != nil {
-		in, out := &in.CreationTime, &out.CreationTime
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
 		*out = (*in).DeepCopy()
 	}
-	if in.DefaultVersion != nil {
-		in, out := &in.DefaultVersion, &out.DefaultVersion
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InvitationAcceptancePolicyStatus.
+func (in *InvitationAcceptancePolicyStatus) DeepCopy() *InvitationAcceptancePolicyStatus {
+	if in == nil {
+		return nil
 	}
-	if in.FeatureSet != nil {
-		in, out := &in.FeatureSet, &out.FeatureSet
-		*out = new(string)
-		**out = **in
+	out := new(InvitationAcceptancePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSharing) DeepCopyInto(out *OrganizationSharing) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSharing.
+func (in *OrganizationSharing) DeepCopy() *OrganizationSharing {
+	if in == nil {
+		return nil
 	}
-	if in.IsResourceTypeDefault != nil {
-		in, out := &in.IsResourceTypeDefault, &out.IsResourceTypeDefault
-		*out = new(bool)
-		**out = **in
+	out := new(OrganizationSharing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationSharing) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.LastUpdatedTime != nil {
-		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
-		*out = (*in).DeepCopy()
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSharingList) DeepCopyInto(out *OrganizationSharingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrganizationSharing, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.PermissionType != nil {
-		in, out := &in.PermissionType, &out.PermissionType
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSharingList.
+func (in *OrganizationSharingList) DeepCopy() *OrganizationSharingList {
+	if in == nil {
+		return nil
 	}
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(string)
-		**out = **in
+	out := new(OrganizationSharingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationSharingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(string)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSharingSpec) DeepCopyInto(out *OrganizationSharingSpec) {
+	*out = *in
+	if in.ResyncPeriodSeconds != nil {
+		in, out := &in.ResyncPeriodSeconds, &out.ResyncPeriodSeconds
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionStatus.
-func (in *PermissionStatus) DeepCopy() *PermissionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSharingSpec.
+func (in *OrganizationSharingSpec) DeepCopy() *OrganizationSharingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PermissionStatus)
+	out := new(OrganizationSharingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Principal) DeepCopyInto(out *Principal) {
+func (in *OrganizationSharingStatus) DeepCopyInto(out *OrganizationSharingStatus) {
 	*out = *in
-	if in.CreationTime != nil {
-		in, out := &in.CreationTime, &out.CreationTime
-		*out = (*in).DeepCopy()
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
 	}
-	if in.External != nil {
-		in, out := &in.External, &out.External
+	if in.SharingEnabled != nil {
+		in, out := &in.SharingEnabled, &out.SharingEnabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
-		*out = new(string)
-		**out = **in
-	}
-	if in.LastUpdatedTime != nil {
-		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
-	if in.ResourceShareARN != nil {
-		in, out := &in.ResourceShareARN, &out.ResourceShareARN
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSharingStatus.
+func (in *OrganizationSharingStatus) DeepCopy() *OrganizationSharingStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(OrganizationSharingStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principal.
-func (in *Principal) DeepCopy() *Principal {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Permission) DeepCopyInto(out *Permission) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permission.
+func (in *Permission) DeepCopy() *Permission {
 	if in == nil {
 		return nil
 	}
-	out := new(Principal)
+	out := new(Permission)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Permission) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplacePermissionAssociationsWork) DeepCopyInto(out *ReplacePermissionAssociationsWork) {
+func (in *PermissionList) DeepCopyInto(out *PermissionList) {
 	*out = *in
-	if in.CreationTime != nil {
-		in, out := &in.CreationTime, &out.CreationTime
-		*out = (*in).DeepCopy()
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Permission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.FromPermissionARN != nil {
-		in, out := &in.FromPermissionARN, &out.FromPermissionARN
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionList.
+func (in *PermissionList) DeepCopy() *PermissionList {
+	if in == nil {
+		return nil
 	}
-	if in.FromPermissionVersion != nil {
-		in, out := &in.FromPermissionVersion, &out.FromPermissionVersion
+	out := new(PermissionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PermissionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionPolicyCondition) DeepCopyInto(out *PermissionPolicyCondition) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
 		*out = new(string)
 		**out = **in
 	}
-	if in.ID != nil {
-		in, out := &in.ID, &out.ID
+	if in.Operator != nil {
+		in, out := &in.Operator, &out.Operator
 		*out = new(string)
 		**out = **in
 	}
-	if in.LastUpdatedTime != nil {
-		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
-		*out = (*in).DeepCopy()
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
 	}
-	if in.StatusMessage != nil {
-		in, out := &in.StatusMessage, &out.StatusMessage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionPolicyCondition.
+func (in *PermissionPolicyCondition) DeepCopy() *PermissionPolicyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionPolicyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionPolicyStatement) DeepCopyInto(out *PermissionPolicyStatement) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*PermissionPolicyCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PermissionPolicyCondition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Effect != nil {
+		in, out := &in.Effect, &out.Effect
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionPolicyStatement.
+func (in *PermissionPolicyStatement) DeepCopy() *PermissionPolicyStatement {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionPolicyStatement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionSpec) DeepCopyInto(out *PermissionSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.PinnedVersion != nil {
+		in, out := &in.PinnedVersion, &out.PinnedVersion
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PolicyStatements != nil {
+		in, out := &in.PolicyStatements, &out.PolicyStatements
+		*out = make([]*PermissionPolicyStatement, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PermissionPolicyStatement)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.PolicyTemplate != nil {
+		in, out := &in.PolicyTemplate, &out.PolicyTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReplacePermissionAssociations != nil {
+		in, out := &in.ReplacePermissionAssociations, &out.ReplacePermissionAssociations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResourceType != nil {
+		in, out := &in.ResourceType, &out.ResourceType
+		*out = new(string)
+		**out = **in
+	}
+	if in.RetainedVersions != nil {
+		in, out := &in.RetainedVersions, &out.RetainedVersions
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]*Tag, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Tag)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionSpec.
+func (in *PermissionSpec) DeepCopy() *PermissionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionStatus) DeepCopyInto(out *PermissionStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ClientTokenNonce != nil {
+		in, out := &in.ClientTokenNonce, &out.ClientTokenNonce
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DefaultVersion != nil {
+		in, out := &in.DefaultVersion, &out.DefaultVersion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FeatureSet != nil {
+		in, out := &in.FeatureSet, &out.FeatureSet
+		*out = new(string)
+		**out = **in
+	}
+	if in.IsResourceTypeDefault != nil {
+		in, out := &in.IsResourceTypeDefault, &out.IsResourceTypeDefault
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastUpdatedTime != nil {
+		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ObservedPolicyTemplate != nil {
+		in, out := &in.ObservedPolicyTemplate, &out.ObservedPolicyTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.PermissionType != nil {
+		in, out := &in.PermissionType, &out.PermissionType
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReplacePermissionAssociationsWork != nil {
+		in, out := &in.ReplacePermissionAssociationsWork, &out.ReplacePermissionAssociationsWork
+		*out = new(ReplacePermissionAssociationsWork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]*ResourceSharePermissionSummary, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceSharePermissionSummary)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionStatus.
+func (in *PermissionStatus) DeepCopy() *PermissionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Principal) DeepCopyInto(out *Principal) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastUpdatedTime != nil {
+		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourceShareARN != nil {
+		in, out := &in.ResourceShareARN, &out.ResourceShareARN
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principal.
+func (in *Principal) DeepCopy() *Principal {
+	if in == nil {
+		return nil
+	}
+	out := new(Principal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrincipalSet) DeepCopyInto(out *PrincipalSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalSet.
+func (in *PrincipalSet) DeepCopy() *PrincipalSet {
+	if in == nil {
+		return nil
+	}
+	out := new(PrincipalSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrincipalSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrincipalSetList) DeepCopyInto(out *PrincipalSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PrincipalSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalSetList.
+func (in *PrincipalSetList) DeepCopy() *PrincipalSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(PrincipalSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrincipalSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrincipalSetSpec) DeepCopyInto(out *PrincipalSetSpec) {
+	*out = *in
+	if in.Principals != nil {
+		in, out := &in.Principals, &out.Principals
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalSetSpec.
+func (in *PrincipalSetSpec) DeepCopy() *PrincipalSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrincipalSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacePermissionAssociationsWork) DeepCopyInto(out *ReplacePermissionAssociationsWork) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FromPermissionARN != nil {
+		in, out := &in.FromPermissionARN, &out.FromPermissionARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.FromPermissionVersion != nil {
+		in, out := &in.FromPermissionVersion, &out.FromPermissionVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastUpdatedTime != nil {
+		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusMessage != nil {
+		in, out := &in.StatusMessage, &out.StatusMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.ToPermissionARN != nil {
+		in, out := &in.ToPermissionARN, &out.ToPermissionARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ToPermissionVersion != nil {
+		in, out := &in.ToPermissionVersion, &out.ToPermissionVersion
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacePermissionAssociationsWork.
+func (in *ReplacePermissionAssociationsWork) DeepCopy() *ReplacePermissionAssociationsWork {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacePermissionAssociationsWork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Resource) DeepCopyInto(out *Resource) {
+	*out = *in
+	if in.ARN != nil {
+		in, out := &in.ARN, &out.ARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdatedTime != nil {
+		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourceGroupARN != nil {
+		in, out := &in.ResourceGroupARN, &out.ResourceGroupARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceShareARN != nil {
+		in, out := &in.ResourceShareARN, &out.ResourceShareARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.StatusMessage != nil {
+		in, out := &in.StatusMessage, &out.StatusMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
+func (in *Resource) DeepCopy() *Resource {
+	if in == nil {
+		return nil
+	}
+	out := new(Resource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
+	*out = *in
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReference.
+func (in *ResourceReference) DeepCopy() *ResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReferenceWrapper) DeepCopyInto(out *ResourceReferenceWrapper) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = new(ResourceReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReferenceWrapper.
+func (in *ResourceReferenceWrapper) DeepCopy() *ResourceReferenceWrapper {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReferenceWrapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+	*out = *in
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(string)
+		**out = **in
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShare) DeepCopyInto(out *ResourceShare) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShare.
+func (in *ResourceShare) DeepCopy() *ResourceShare {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceShare)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceShare) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShareAssociation) DeepCopyInto(out *ResourceShareAssociation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareAssociation.
+func (in *ResourceShareAssociation) DeepCopy() *ResourceShareAssociation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceShareAssociation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceShareAssociation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShareAssociationList) DeepCopyInto(out *ResourceShareAssociationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceShareAssociation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareAssociationList.
+func (in *ResourceShareAssociationList) DeepCopy() *ResourceShareAssociationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceShareAssociationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceShareAssociationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShareAssociationSpec) DeepCopyInto(out *ResourceShareAssociationSpec) {
+	*out = *in
+	if in.Principal != nil {
+		in, out := &in.Principal, &out.Principal
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceARN != nil {
+		in, out := &in.ResourceARN, &out.ResourceARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceShareARN != nil {
+		in, out := &in.ResourceShareARN, &out.ResourceShareARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourceShareRef != nil {
+		in, out := &in.ResourceShareRef, &out.ResourceShareRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareAssociationSpec.
+func (in *ResourceShareAssociationSpec) DeepCopy() *ResourceShareAssociationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceShareAssociationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShareAssociationStatus) DeepCopyInto(out *ResourceShareAssociationStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.AssociationType != nil {
+		in, out := &in.AssociationType, &out.AssociationType
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientTokenNonce != nil {
+		in, out := &in.ClientTokenNonce, &out.ClientTokenNonce
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastUpdatedTime != nil {
+		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourceShareName != nil {
+		in, out := &in.ResourceShareName, &out.ResourceShareName
 		*out = new(string)
 		**out = **in
 	}
-	if in.ToPermissionARN != nil {
-		in, out := &in.ToPermissionARN, &out.ToPermissionARN
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
 		*out = new(string)
 		**out = **in
 	}
-	if in.ToPermissionVersion != nil {
-		in, out := &in.ToPermissionVersion, &out.ToPermissionVersion
+	if in.StatusMessage != nil {
+		in, out := &in.StatusMessage, &out.StatusMessage
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacePermissionAssociationsWork.
-func (in *ReplacePermissionAssociationsWork) DeepCopy() *ReplacePermissionAssociationsWork {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareAssociationStatus.
+func (in *ResourceShareAssociationStatus) DeepCopy() *ResourceShareAssociationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplacePermissionAssociationsWork)
+	out := new(ResourceShareAssociationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Resource) DeepCopyInto(out *Resource) {
+func (in *ResourceShareAssociation_SDK) DeepCopyInto(out *ResourceShareAssociation_SDK) {
 	*out = *in
-	if in.ARN != nil {
-		in, out := &in.ARN, &out.ARN
+	if in.AssociatedEntity != nil {
+		in, out := &in.AssociatedEntity, &out.AssociatedEntity
+		*out = new(string)
+		**out = **in
+	}
+	if in.AssociationType != nil {
+		in, out := &in.AssociationType, &out.AssociationType
 		*out = new(string)
 		**out = **in
 	}
@@ -350,44 +1174,49 @@ func (in *Resource) DeepCopyInto(out *Resource) {
 		in, out := &in.CreationTime, &out.CreationTime
 		*out = (*in).DeepCopy()
 	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(bool)
+		**out = **in
+	}
 	if in.LastUpdatedTime != nil {
 		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
 		*out = (*in).DeepCopy()
 	}
-	if in.ResourceGroupARN != nil {
-		in, out := &in.ResourceGroupARN, &out.ResourceGroupARN
+	if in.ResourceShareARN != nil {
+		in, out := &in.ResourceShareARN, &out.ResourceShareARN
 		*out = new(string)
 		**out = **in
 	}
-	if in.ResourceShareARN != nil {
-		in, out := &in.ResourceShareARN, &out.ResourceShareARN
+	if in.ResourceShareName != nil {
+		in, out := &in.ResourceShareName, &out.ResourceShareName
 		*out = new(string)
 		**out = **in
 	}
-	if in.StatusMessage != nil {
-		in, out := &in.StatusMessage, &out.StatusMessage
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
 		*out = new(string)
 		**out = **in
 	}
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
+	if in.StatusMessage != nil {
+		in, out := &in.StatusMessage, &out.StatusMessage
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
-func (in *Resource) DeepCopy() *Resource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareAssociation_SDK.
+func (in *ResourceShareAssociation_SDK) DeepCopy() *ResourceShareAssociation_SDK {
 	if in == nil {
 		return nil
 	}
-	out := new(Resource)
+	out := new(ResourceShareAssociation_SDK)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceShare) DeepCopyInto(out *ResourceShare) {
+func (in *ResourceShareInvitation) DeepCopyInto(out *ResourceShareInvitation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -395,18 +1224,18 @@ func (in *ResourceShare) DeepCopyInto(out *ResourceShare) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShare.
-func (in *ResourceShare) DeepCopy() *ResourceShare {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareInvitation.
+func (in *ResourceShareInvitation) DeepCopy() *ResourceShareInvitation {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceShare)
+	out := new(ResourceShareInvitation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ResourceShare) DeepCopyObject() runtime.Object {
+func (in *ResourceShareInvitation) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -414,28 +1243,97 @@ func (in *ResourceShare) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceShareAssociation) DeepCopyInto(out *ResourceShareAssociation) {
+func (in *ResourceShareInvitationList) DeepCopyInto(out *ResourceShareInvitationList) {
 	*out = *in
-	if in.AssociatedEntity != nil {
-		in, out := &in.AssociatedEntity, &out.AssociatedEntity
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceShareInvitation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareInvitationList.
+func (in *ResourceShareInvitationList) DeepCopy() *ResourceShareInvitationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceShareInvitationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceShareInvitationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShareInvitationSpec) DeepCopyInto(out *ResourceShareInvitationSpec) {
+	*out = *in
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
 		*out = new(string)
 		**out = **in
 	}
-	if in.CreationTime != nil {
-		in, out := &in.CreationTime, &out.CreationTime
-		*out = (*in).DeepCopy()
+	if in.ResourceShareARN != nil {
+		in, out := &in.ResourceShareARN, &out.ResourceShareARN
+		*out = new(string)
+		**out = **in
 	}
-	if in.External != nil {
-		in, out := &in.External, &out.External
-		*out = new(bool)
+	if in.SenderAccountID != nil {
+		in, out := &in.SenderAccountID, &out.SenderAccountID
+		*out = new(string)
 		**out = **in
 	}
-	if in.LastUpdatedTime != nil {
-		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareInvitationSpec.
+func (in *ResourceShareInvitationSpec) DeepCopy() *ResourceShareInvitationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceShareInvitationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShareInvitationStatus) DeepCopyInto(out *ResourceShareInvitationStatus) {
+	*out = *in
+	if in.ACKResourceMetadata != nil {
+		in, out := &in.ACKResourceMetadata, &out.ACKResourceMetadata
+		*out = new(corev1alpha1.ResourceMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]*corev1alpha1.Condition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.Condition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.InvitationTimestamp != nil {
+		in, out := &in.InvitationTimestamp, &out.InvitationTimestamp
 		*out = (*in).DeepCopy()
 	}
-	if in.ResourceShareARN != nil {
-		in, out := &in.ResourceShareARN, &out.ResourceShareARN
+	if in.ReceiverAccountID != nil {
+		in, out := &in.ReceiverAccountID, &out.ReceiverAccountID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReceiverARN != nil {
+		in, out := &in.ReceiverARN, &out.ReceiverARN
 		*out = new(string)
 		**out = **in
 	}
@@ -444,25 +1342,25 @@ func (in *ResourceShareAssociation) DeepCopyInto(out *ResourceShareAssociation)
 		*out = new(string)
 		**out = **in
 	}
-	if in.StatusMessage != nil {
-		in, out := &in.StatusMessage, &out.StatusMessage
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareAssociation.
-func (in *ResourceShareAssociation) DeepCopy() *ResourceShareAssociation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareInvitationStatus.
+func (in *ResourceShareInvitationStatus) DeepCopy() *ResourceShareInvitationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceShareAssociation)
+	out := new(ResourceShareInvitationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceShareInvitation) DeepCopyInto(out *ResourceShareInvitation) {
+func (in *ResourceShareInvitation_SDK) DeepCopyInto(out *ResourceShareInvitation_SDK) {
 	*out = *in
 	if in.InvitationTimestamp != nil {
 		in, out := &in.InvitationTimestamp, &out.InvitationTimestamp
@@ -500,12 +1398,12 @@ func (in *ResourceShareInvitation) DeepCopyInto(out *ResourceShareInvitation) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareInvitation.
-func (in *ResourceShareInvitation) DeepCopy() *ResourceShareInvitation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShareInvitation_SDK.
+func (in *ResourceShareInvitation_SDK) DeepCopy() *ResourceShareInvitation_SDK {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceShareInvitation)
+	out := new(ResourceShareInvitation_SDK)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -713,6 +1611,11 @@ func (in *ResourceShareSpec) DeepCopyInto(out *ResourceShareSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AssociationMode != nil {
+		in, out := &in.AssociationMode, &out.AssociationMode
+		*out = new(string)
+		**out = **in
+	}
 	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
@@ -751,6 +1654,33 @@ func (in *ResourceShareSpec) DeepCopyInto(out *ResourceShareSpec) {
 			}
 		}
 	}
+	if in.PrincipalRefs != nil {
+		in, out := &in.PrincipalRefs, &out.PrincipalRefs
+		*out = make([]*ResourceReferenceWrapper, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceReferenceWrapper)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.PrincipalSetRefs != nil {
+		in, out := &in.PrincipalSetRefs, &out.PrincipalSetRefs
+		*out = make([]*corev1alpha1.AWSResourceReferenceWrapper, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.PromoteCreatedFromPolicy != nil {
+		in, out := &in.PromoteCreatedFromPolicy, &out.PromoteCreatedFromPolicy
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ResourceARNs != nil {
 		in, out := &in.ResourceARNs, &out.ResourceARNs
 		*out = make([]*string, len(*in))
@@ -762,6 +1692,33 @@ func (in *ResourceShareSpec) DeepCopyInto(out *ResourceShareSpec) {
 			}
 		}
 	}
+	if in.ResourceRefs != nil {
+		in, out := &in.ResourceRefs, &out.ResourceRefs
+		*out = make([]*ResourceReferenceWrapper, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceReferenceWrapper)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ResourceSelectors != nil {
+		in, out := &in.ResourceSelectors, &out.ResourceSelectors
+		*out = make([]*ResourceSelector, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceSelector)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.SelectionRevision != nil {
+		in, out := &in.SelectionRevision, &out.SelectionRevision
+		*out = new(string)
+		**out = **in
+	}
 	if in.Sources != nil {
 		in, out := &in.Sources, &out.Sources
 		*out = make([]*string, len(*in))
@@ -815,6 +1772,11 @@ func (in *ResourceShareStatus) DeepCopyInto(out *ResourceShareStatus) {
 			}
 		}
 	}
+	if in.ClientTokenNonce != nil {
+		in, out := &in.ClientTokenNonce, &out.ClientTokenNonce
+		*out = new(int64)
+		**out = **in
+	}
 	if in.CreationTime != nil {
 		in, out := &in.CreationTime, &out.CreationTime
 		*out = (*in).DeepCopy()
@@ -828,11 +1790,99 @@ func (in *ResourceShareStatus) DeepCopyInto(out *ResourceShareStatus) {
 		in, out := &in.LastUpdatedTime, &out.LastUpdatedTime
 		*out = (*in).DeepCopy()
 	}
+	if in.ManagedPrincipals != nil {
+		in, out := &in.ManagedPrincipals, &out.ManagedPrincipals
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ManagedResourceARNs != nil {
+		in, out := &in.ManagedResourceARNs, &out.ManagedResourceARNs
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ManagedSources != nil {
+		in, out := &in.ManagedSources, &out.ManagedSources
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
 	if in.OwningAccountID != nil {
 		in, out := &in.OwningAccountID, &out.OwningAccountID
 		*out = new(string)
 		**out = **in
 	}
+	if in.PrincipalAssociations != nil {
+		in, out := &in.PrincipalAssociations, &out.PrincipalAssociations
+		*out = make([]*ResourceShareAssociation_SDK, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceShareAssociation_SDK)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.PrincipalsFromAssociations != nil {
+		in, out := &in.PrincipalsFromAssociations, &out.PrincipalsFromAssociations
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.PromotedPermissionARNs != nil {
+		in, out := &in.PromotedPermissionARNs, &out.PromotedPermissionARNs
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ResourceARNsFromAssociations != nil {
+		in, out := &in.ResourceARNsFromAssociations, &out.ResourceARNsFromAssociations
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ResourceAssociations != nil {
+		in, out := &in.ResourceAssociations, &out.ResourceAssociations
+		*out = make([]*ResourceShareAssociation_SDK, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceShareAssociation_SDK)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(string)
@@ -37,10 +37,17 @@ import (
 	ctrlrtwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	svctypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+	invitationpolicy "github.com/aws-controllers-k8s/ram-controller/pkg/invitation_policy"
+	organizationsharing "github.com/aws-controllers-k8s/ram-controller/pkg/organization_sharing"
 	svcresource "github.com/aws-controllers-k8s/ram-controller/pkg/resource"
+	resourceselector "github.com/aws-controllers-k8s/ram-controller/pkg/resource_selector"
+	svcwebhook "github.com/aws-controllers-k8s/ram-controller/pkg/webhook"
 
 	_ "github.com/aws-controllers-k8s/ram-controller/pkg/resource/permission"
 	_ "github.com/aws-controllers-k8s/ram-controller/pkg/resource/resource_share"
+	_ "github.com/aws-controllers-k8s/ram-controller/pkg/resource/resource_share_association"
+	_ "github.com/aws-controllers-k8s/ram-controller/pkg/resource/resource_share_invitation"
 
 	"github.com/aws-controllers-k8s/ram-controller/pkg/version"
 )
@@ -62,6 +69,7 @@ func init() {
 func main() {
 	var ackCfg ackcfg.Config
 	ackCfg.BindFlags()
+	svcconfig.BindFlags()
 	flag.Parse()
 	ackCfg.SetupLogger()
 
@@ -79,6 +87,13 @@ func main() {
 		)
 		os.Exit(1)
 	}
+	if err := svcconfig.Validate(); err != nil {
+		setupLog.Error(
+			err, "Unable to create controller manager",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
 
 	host, port, err := ackrtutil.GetHostPort(ackCfg.WebhookServerAddr)
 	if err != nil {
@@ -161,6 +176,13 @@ func main() {
 	)
 
 	if ackCfg.EnableWebhookServer {
+		if err := svcwebhook.Register(ackCfg.Region); err != nil {
+			setupLog.Error(
+				err, "unable to register ResourceShare validating webhook",
+				"aws.service", awsServiceAlias,
+			)
+			os.Exit(1)
+		}
 		webhooks := ackrtwebhook.GetWebhooks()
 		for _, webhook := range webhooks {
 			if err := webhook.Setup(mgr); err != nil {
@@ -180,6 +202,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = invitationpolicy.SetupWithManager(ctx, mgr, sc, ackCfg); err != nil {
+		setupLog.Error(
+			err, "unable to set up invitation acceptance policy reconciler",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
+	if err = organizationsharing.SetupWithManager(ctx, mgr, sc, ackCfg); err != nil {
+		setupLog.Error(
+			err, "unable to set up organization sharing reconciler",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
+	if err = resourceselector.SetupWithManager(mgr, sc); err != nil {
+		setupLog.Error(
+			err, "unable to set up resource share selector reconciler",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
 	if err = mgr.AddHealthzCheck("health", ctrlrthealthz.Ping); err != nil {
 		setupLog.Error(
 			err, "unable to set up health check",
@@ -61,6 +61,13 @@ rules:
   - list
   - patch
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - create
+  - patch
 - apiGroups:
   - ""
   resources:
@@ -69,10 +76,34 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - ec2.services.k8s.aws
+  - networkfirewall.services.k8s.aws
+  - organizations.services.k8s.aws
+  - rds.services.k8s.aws
+  - route53resolver.services.k8s.aws
+  resources:
+  - '*'
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - ram.services.k8s.aws
+  resources:
+  - invitationacceptancepolicies
+  - organizationsharings
+  - principalsets
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - ram.services.k8s.aws
   resources:
   - permissions
+  - resourceshareassociations
+  - resourceshareinvitations
   - resourceshares
   verbs:
   - create
@@ -85,7 +116,11 @@ rules:
 - apiGroups:
   - ram.services.k8s.aws
   resources:
+  - invitationacceptancepolicies/status
+  - organizationsharings/status
   - permissions/status
+  - resourceshareassociations/status
+  - resourceshareinvitations/status
   - resourceshares/status
   verbs:
   - get
@@ -0,0 +1,73 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package config holds the RAM specific settings of the controller, which
+// are bound to command line flags next to the ones of the ACK runtime.
+package config
+
+import (
+	"fmt"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// FlagListPageSize is the name of the flag setting ListPageSize.
+	FlagListPageSize = "ram-list-page-size"
+	// FlagListMaxPages is the name of the flag setting ListMaxPages.
+	FlagListMaxPages = "ram-list-max-pages"
+
+	// maxListPageSize is the largest MaxResults the RAM list calls accept.
+	maxListPageSize = 500
+)
+
+var (
+	// ListPageSize is the number of items requested per page by the RAM list
+	// calls.
+	ListPageSize int32 = 100
+	// ListMaxPages is the number of pages a RAM list call may return for a
+	// single resource before the controller gives up reading it.
+	ListMaxPages int = 50
+)
+
+// BindFlags defines the RAM specific CLI flags.
+func BindFlags() {
+	flag.Int32Var(
+		&ListPageSize, FlagListPageSize,
+		ListPageSize,
+		"The number of items requested per page by the RAM list calls.",
+	)
+	flag.IntVar(
+		&ListMaxPages, FlagListMaxPages,
+		ListMaxPages,
+		"The maximum number of pages read from a RAM list call for a single "+
+			"resource. Resources exceeding it are reported with a terminal condition.",
+	)
+}
+
+// Validate ensures the RAM specific flags have usable values.
+func Validate() error {
+	if ListPageSize < 1 || ListPageSize > maxListPageSize {
+		return fmt.Errorf(
+			"invalid value for flag '%s': must be between 1 and %d",
+			FlagListPageSize, maxListPageSize,
+		)
+	}
+	if ListMaxPages < 1 {
+		return fmt.Errorf(
+			"invalid value for flag '%s': must be at least 1",
+			FlagListMaxPages,
+		)
+	}
+	return nil
+}
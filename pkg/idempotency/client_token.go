@@ -0,0 +1,73 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package idempotency derives the client tokens the controller sends to the
+// RAM calls that create or associate things. RAM returns the result of the
+// first call made with a given client token instead of repeating it, so a
+// call retried after a controller crash or a network timeout converges on
+// the same AWS object instead of creating a duplicate. Each object carries a
+// nonce in its status that the controller bumps once its calls succeeded, so
+// that a call legitimately made again, for example to associate a principal
+// disassociated out of band, isn't answered with the cached result of the
+// earlier one.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClientToken returns the client token of the supplied operation made on
+// behalf of the supplied object. The token is derived from the UID and the
+// generation of the object, the supplied nonce, the operation and the
+// supplied parameters, the order of the values of each parameter being
+// irrelevant. Calls made for a different generation of the object, after
+// the nonce was bumped, or with different parameters, get a different token,
+// since RAM rejects a token reused with other parameters.
+func ClientToken(
+	obj metav1.Object,
+	nonce *int64,
+	operation string,
+	params ...[]string,
+) *string {
+	h := sha256.New()
+	h.Write([]byte(obj.GetUID()))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(obj.GetGeneration(), 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(aws.ToInt64(nonce), 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(operation))
+	for _, param := range params {
+		values := append([]string{}, param...)
+		sort.Strings(values)
+		h.Write([]byte{1})
+		for _, v := range values {
+			h.Write([]byte{0})
+			h.Write([]byte(v))
+		}
+	}
+	return aws.String(hex.EncodeToString(h.Sum(nil)))
+}
+
+// NextNonce returns the nonce following the supplied one. It is recorded in
+// the status of an object once the calls made with the supplied nonce
+// succeeded.
+func NextNonce(nonce *int64) *int64 {
+	return aws.Int64(aws.ToInt64(nonce) + 1)
+}
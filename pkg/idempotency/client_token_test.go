@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package idempotency
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClientToken(t *testing.T) {
+	obj := &metav1.ObjectMeta{UID: "6b0d4a9e-0a6c-4d47-8f0b-2b1f3c1d7e11", Generation: 1}
+	base := ClientToken(obj, nil, "AssociateResourceShare", []string{"111122223333", "444455556666"})
+
+	tests := []struct {
+		name      string
+		obj       *metav1.ObjectMeta
+		nonce     *int64
+		operation string
+		params    [][]string
+		wantSame  bool
+	}{
+		{
+			name:      "same call",
+			obj:       obj,
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"111122223333", "444455556666"}},
+			wantSame:  true,
+		},
+		{
+			name:      "parameter values in another order",
+			obj:       obj,
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"444455556666", "111122223333"}},
+			wantSame:  true,
+		},
+		{
+			name:      "zero nonce",
+			obj:       obj,
+			nonce:     aws.Int64(0),
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"111122223333", "444455556666"}},
+			wantSame:  true,
+		},
+		{
+			name:      "bumped nonce",
+			obj:       obj,
+			nonce:     NextNonce(nil),
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"111122223333", "444455556666"}},
+		},
+		{
+			name:      "another generation",
+			obj:       &metav1.ObjectMeta{UID: obj.UID, Generation: 2},
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"111122223333", "444455556666"}},
+		},
+		{
+			name:      "another object",
+			obj:       &metav1.ObjectMeta{UID: "0f3e0a55-5b7e-4a8c-9d3c-3c4b9a0e6f22", Generation: 1},
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"111122223333", "444455556666"}},
+		},
+		{
+			name:      "another operation",
+			obj:       obj,
+			operation: "CreateResourceShare",
+			params:    [][]string{{"111122223333", "444455556666"}},
+		},
+		{
+			name:      "other parameter values",
+			obj:       obj,
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"111122223333"}},
+		},
+		{
+			name:      "parameter values split across parameters",
+			obj:       obj,
+			operation: "AssociateResourceShare",
+			params:    [][]string{{"111122223333"}, {"444455556666"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClientToken(tt.obj, tt.nonce, tt.operation, tt.params...)
+			if same := *got == *base; same != tt.wantSame {
+				t.Errorf("ClientToken() = %s, same as the base token = %v, want %v", *got, same, tt.wantSame)
+			}
+			if len(*got) > 64 {
+				t.Errorf("ClientToken() = %s, longer than the 64 characters RAM accepts", *got)
+			}
+		})
+	}
+}
+
+func TestNextNonce(t *testing.T) {
+	if got := aws.ToInt64(NextNonce(nil)); got != 1 {
+		t.Errorf("NextNonce(nil) = %d, want 1", got)
+	}
+	if got := aws.ToInt64(NextNonce(aws.Int64(4))); got != 5 {
+		t.Errorf("NextNonce(4) = %d, want 5", got)
+	}
+}
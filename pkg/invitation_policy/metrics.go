@@ -0,0 +1,45 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package invitation_policy
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlrtmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	outcomeAccepted = "accepted"
+	outcomeRejected = "rejected"
+	outcomeIgnored  = "ignored"
+)
+
+var (
+	invitationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ack_ram_invitation_policy_invitations_total",
+			Help: "Total number of pending resource share invitations evaluated by an invitation acceptance policy, by outcome.",
+		},
+		[]string{"policy", "outcome"},
+	)
+)
+
+func init() {
+	ctrlrtmetrics.Registry.MustRegister(invitationsTotal)
+}
+
+// recordInvitation increments the invitation counter of the supplied policy
+// for the supplied outcome.
+func recordInvitation(policy string, outcome string) {
+	invitationsTotal.WithLabelValues(policy, outcome).Inc()
+}
@@ -0,0 +1,539 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package invitation_policy contains the reconciler for
+// InvitationAcceptancePolicy resources. Unlike the resources under
+// pkg/resource, a policy doesn't map to an AWS resource: it periodically
+// polls RAM for the pending resource share invitations received by the
+// controller's AWS account and responds to the ones that match it.
+package invitation_policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+)
+
+// +kubebuilder:rbac:groups=ram.services.k8s.aws,resources=invitationacceptancepolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ram.services.k8s.aws,resources=invitationacceptancepolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+const (
+	controllerName = "invitationacceptancepolicy"
+	serviceAlias   = "ram"
+
+	actionReject = "REJECT"
+
+	defaultPollInterval = 300 * time.Second
+)
+
+// Reconciler polls RAM for pending resource share invitations and responds
+// to the ones matching an InvitationAcceptancePolicy.
+type Reconciler struct {
+	kc       client.Client
+	log      logr.Logger
+	recorder record.EventRecorder
+	metrics  *ackmetrics.Metrics
+	sdkapi   *svcsdk.Client
+
+	mu sync.Mutex
+	// ignored holds, for each policy, the reason why it ignored each pending
+	// invitation.
+	ignored map[types.UID]map[string]string
+}
+
+// SetupWithManager creates a Reconciler that uses the AWS account and
+// region the service controller is configured with, and registers it with
+// the supplied manager.
+func SetupWithManager(
+	ctx context.Context,
+	mgr ctrlrt.Manager,
+	sc acktypes.ServiceController,
+	cfg ackcfg.Config,
+) error {
+	gvk := svcapitypes.GroupVersion.WithKind("InvitationAcceptancePolicy")
+	clientcfg, err := sc.NewAWSConfig(
+		ctx, ackv1alpha1.AWSRegion(cfg.Region), &cfg.EndpointURL, "", gvk, nil,
+	)
+	if err != nil {
+		return err
+	}
+	r := &Reconciler{
+		kc:       mgr.GetClient(),
+		log:      ctrlrt.Log.WithName("ackrt").WithName(controllerName),
+		recorder: mgr.GetEventRecorderFor("ack-ram-controller"),
+		metrics:  ackmetrics.NewMetrics(serviceAlias),
+		sdkapi:   svcsdk.NewFromConfig(clientcfg),
+		ignored:  map[types.UID]map[string]string{},
+	}
+	return ctrlrt.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		// Status updates don't change the generation, which keeps them from
+		// triggering a poll outside of the policy's poll interval.
+		For(
+			&svcapitypes.InvitationAcceptancePolicy{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// Reconcile polls RAM for the pending invitations, responds to the ones
+// matching the policy and requeues the policy after its poll interval.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrlrt.Request) (ctrlrt.Result, error) {
+	policy := &svcapitypes.InvitationAcceptancePolicy{}
+	if err := r.kc.Get(ctx, req.NamespacedName, policy); err != nil {
+		return ctrlrt.Result{}, client.IgnoreNotFound(err)
+	}
+	if !policy.DeletionTimestamp.IsZero() {
+		r.mu.Lock()
+		delete(r.ignored, policy.UID)
+		r.mu.Unlock()
+		return ctrlrt.Result{}, nil
+	}
+	log := r.log.WithValues("policy", policy.Name)
+
+	pollErr := r.poll(ctx, policy)
+	if pollErr != nil {
+		log.Error(pollErr, "failed to respond to pending resource share invitations")
+	}
+
+	patch := client.MergeFrom(policy.DeepCopy())
+	now := metav1.Now()
+	policy.Status.LastPollTime = &now
+	setSyncedCondition(policy, pollErr)
+	if err := r.kc.Status().Patch(ctx, policy, patch); err != nil {
+		return ctrlrt.Result{}, err
+	}
+	return ctrlrt.Result{RequeueAfter: pollInterval(policy)}, nil
+}
+
+// poll lists the invitations received by the account and responds to the
+// pending ones that match the policy. Invitations for resource shares that
+// have a ResourceShareInvitation resource are left to that resource.
+func (r *Reconciler) poll(
+	ctx context.Context,
+	policy *svcapitypes.InvitationAcceptancePolicy,
+) error {
+	managed, err := r.managedResourceShareARNs(ctx)
+	if err != nil {
+		return err
+	}
+	policies, err := r.otherPolicies(ctx, policy)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	pending := map[string]struct{}{}
+	tags := r.resourceShareTags()
+	input := &svcsdk.GetResourceShareInvitationsInput{
+		MaxResults: aws.Int32(svcconfig.ListPageSize),
+	}
+	for pages := 1; ; pages++ {
+		resp, err := r.sdkapi.GetResourceShareInvitations(ctx, input)
+		r.metrics.RecordAPICall("READ_MANY", "GetResourceShareInvitations", err)
+		if err != nil {
+			return err
+		}
+		for _, inv := range resp.ResourceShareInvitations {
+			if inv.Status != svcsdktypes.ResourceShareInvitationStatusPending {
+				continue
+			}
+			if _, ok := managed[aws.ToString(inv.ResourceShareArn)]; ok {
+				continue
+			}
+			pending[aws.ToString(inv.ResourceShareInvitationArn)] = struct{}{}
+			if err := r.evaluate(ctx, policy, policies, inv, tags); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if resp.NextToken == nil {
+			r.forgetIgnored(policy, pending)
+			break
+		}
+		if pages >= svcconfig.ListMaxPages {
+			errs = append(errs, fmt.Errorf(
+				"GetResourceShareInvitations returned more than %d pages of %d items, increase --%s to evaluate every invitation",
+				svcconfig.ListMaxPages, svcconfig.ListPageSize, svcconfig.FlagListMaxPages,
+			))
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return errors.Join(errs...)
+}
+
+// evaluate responds to the supplied pending invitation if it matches the
+// policy and no other policy takes precedence, and records the outcome.
+// Ignoring an invitation is only recorded the first time, or when the reason
+// changes, as the invitation stays pending and is evaluated on every poll.
+func (r *Reconciler) evaluate(
+	ctx context.Context,
+	policy *svcapitypes.InvitationAcceptancePolicy,
+	policies []svcapitypes.InvitationAcceptancePolicy,
+	inv svcsdktypes.ResourceShareInvitation,
+	tags tagsFunc,
+) error {
+	invitationARN := aws.ToString(inv.ResourceShareInvitationArn)
+	shareName := aws.ToString(inv.ResourceShareName)
+	sender := aws.ToString(inv.SenderAccountId)
+
+	reason, err := mismatchReason(ctx, policy, inv, tags)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		if other := precedingPolicy(ctx, policy, policies, inv, tags); other != nil {
+			reason = fmt.Sprintf("policy %s takes precedence", other.Name)
+		}
+	}
+	if reason != "" {
+		if r.rememberIgnored(policy, invitationARN, reason) {
+			recordInvitation(policy.Name, outcomeIgnored)
+			r.recorder.Eventf(
+				policy, corev1.EventTypeNormal, "InvitationIgnored",
+				"Ignored invitation %s to resource share %q from account %s: %s",
+				invitationARN, shareName, sender, reason,
+			)
+		}
+		return nil
+	}
+
+	if isReject(policy) {
+		_, err = r.sdkapi.RejectResourceShareInvitation(
+			ctx,
+			&svcsdk.RejectResourceShareInvitationInput{
+				ResourceShareInvitationArn: inv.ResourceShareInvitationArn,
+			},
+		)
+		r.metrics.RecordAPICall("UPDATE", "RejectResourceShareInvitation", err)
+		if err == nil {
+			recordInvitation(policy.Name, outcomeRejected)
+			r.recorder.Eventf(
+				policy, corev1.EventTypeNormal, "InvitationRejected",
+				"Rejected invitation %s to resource share %q from account %s",
+				invitationARN, shareName, sender,
+			)
+		}
+	} else {
+		_, err = r.sdkapi.AcceptResourceShareInvitation(
+			ctx,
+			&svcsdk.AcceptResourceShareInvitationInput{
+				ResourceShareInvitationArn: inv.ResourceShareInvitationArn,
+			},
+		)
+		r.metrics.RecordAPICall("UPDATE", "AcceptResourceShareInvitation", err)
+		if err == nil {
+			recordInvitation(policy.Name, outcomeAccepted)
+			r.recorder.Eventf(
+				policy, corev1.EventTypeNormal, "InvitationAccepted",
+				"Accepted invitation %s to resource share %q from account %s",
+				invitationARN, shareName, sender,
+			)
+		}
+	}
+	if err != nil {
+		r.recorder.Eventf(
+			policy, corev1.EventTypeWarning, "InvitationResponseFailed",
+			"Failed to respond to invitation %s to resource share %q from account %s: %s",
+			invitationARN, shareName, sender, err,
+		)
+		return fmt.Errorf("responding to invitation %s: %w", invitationARN, err)
+	}
+	return nil
+}
+
+// rememberIgnored remembers that the policy ignored the supplied invitation
+// for the supplied reason, and returns whether it didn't already.
+func (r *Reconciler) rememberIgnored(
+	policy *svcapitypes.InvitationAcceptancePolicy,
+	invitationARN string,
+	reason string,
+) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ignored, ok := r.ignored[policy.UID]
+	if !ok {
+		ignored = map[string]string{}
+		r.ignored[policy.UID] = ignored
+	}
+	if ignored[invitationARN] == reason {
+		return false
+	}
+	ignored[invitationARN] = reason
+	return true
+}
+
+// forgetIgnored forgets the invitations ignored by the policy that are no
+// longer pending.
+func (r *Reconciler) forgetIgnored(
+	policy *svcapitypes.InvitationAcceptancePolicy,
+	pending map[string]struct{},
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for invitationARN := range r.ignored[policy.UID] {
+		if _, ok := pending[invitationARN]; !ok {
+			delete(r.ignored[policy.UID], invitationARN)
+		}
+	}
+}
+
+// otherPolicies returns the policies other than the supplied one that
+// aren't being deleted, sorted by name.
+func (r *Reconciler) otherPolicies(
+	ctx context.Context,
+	policy *svcapitypes.InvitationAcceptancePolicy,
+) ([]svcapitypes.InvitationAcceptancePolicy, error) {
+	list := &svcapitypes.InvitationAcceptancePolicyList{}
+	if err := r.kc.List(ctx, list); err != nil {
+		return nil, err
+	}
+	policies := make([]svcapitypes.InvitationAcceptancePolicy, 0, len(list.Items))
+	for _, item := range list.Items {
+		if item.UID != policy.UID && item.DeletionTimestamp.IsZero() {
+			policies = append(policies, item)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies, nil
+}
+
+// precedingPolicy returns the first of the supplied policies that matches
+// the invitation and takes precedence over the supplied policy, if any. A
+// policy rejecting invitations takes precedence over a policy accepting
+// them, and among policies with the same action the one whose name sorts
+// first takes precedence. Policies that fail to evaluate the invitation
+// don't match it.
+func precedingPolicy(
+	ctx context.Context,
+	policy *svcapitypes.InvitationAcceptancePolicy,
+	policies []svcapitypes.InvitationAcceptancePolicy,
+	inv svcsdktypes.ResourceShareInvitation,
+	tags tagsFunc,
+) *svcapitypes.InvitationAcceptancePolicy {
+	for i := range policies {
+		other := &policies[i]
+		if !precedes(other, policy) {
+			continue
+		}
+		if reason, err := mismatchReason(ctx, other, inv, tags); err == nil && reason == "" {
+			return other
+		}
+	}
+	return nil
+}
+
+// precedes returns whether policy a takes precedence over policy b when they
+// both match an invitation.
+func precedes(a, b *svcapitypes.InvitationAcceptancePolicy) bool {
+	if isReject(a) != isReject(b) {
+		return isReject(a)
+	}
+	return a.Name < b.Name
+}
+
+// isReject returns whether the policy rejects the invitations it matches.
+func isReject(policy *svcapitypes.InvitationAcceptancePolicy) bool {
+	return aws.ToString(policy.Spec.Action) == actionReject
+}
+
+// tagsFunc returns the tags of the supplied resource share shared with the
+// account, or nil if the resource share isn't visible.
+type tagsFunc func(ctx context.Context, resourceShareARN string) (map[string]string, error)
+
+// mismatchReason returns why the supplied invitation doesn't match the
+// policy, or an empty string if it does.
+func mismatchReason(
+	ctx context.Context,
+	policy *svcapitypes.InvitationAcceptancePolicy,
+	inv svcsdktypes.ResourceShareInvitation,
+	tags tagsFunc,
+) (string, error) {
+	sender := aws.ToString(inv.SenderAccountId)
+	trusted := false
+	for _, id := range policy.Spec.TrustedSenderAccountIDs {
+		if id != nil && *id == sender {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return "sender account is not trusted", nil
+	}
+
+	if len(policy.Spec.ResourceShareNamePatterns) > 0 {
+		name := aws.ToString(inv.ResourceShareName)
+		matched := false
+		for _, pattern := range policy.Spec.ResourceShareNamePatterns {
+			if pattern == nil {
+				continue
+			}
+			ok, err := path.Match(*pattern, name)
+			if err != nil {
+				return "", fmt.Errorf("invalid resource share name pattern %q: %w", *pattern, err)
+			}
+			if ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "resource share name matches none of the patterns", nil
+		}
+	}
+
+	if len(policy.Spec.RequiredTags) > 0 {
+		shareTags, err := tags(ctx, aws.ToString(inv.ResourceShareArn))
+		if err != nil {
+			return "", err
+		}
+		if shareTags == nil {
+			return "resource share tags can't be read", nil
+		}
+		for _, t := range policy.Spec.RequiredTags {
+			if t == nil || t.Key == nil {
+				continue
+			}
+			v, ok := shareTags[*t.Key]
+			if !ok || v != aws.ToString(t.Value) {
+				return fmt.Sprintf("resource share is missing tag %q", *t.Key), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// resourceShareTags returns a tagsFunc that reads the tags of each resource
+// share at most once.
+func (r *Reconciler) resourceShareTags() tagsFunc {
+	cache := map[string]map[string]string{}
+	return func(ctx context.Context, resourceShareARN string) (map[string]string, error) {
+		if tags, ok := cache[resourceShareARN]; ok {
+			return tags, nil
+		}
+		tags, err := r.getResourceShareTags(ctx, resourceShareARN)
+		if err != nil {
+			return nil, err
+		}
+		cache[resourceShareARN] = tags
+		return tags, nil
+	}
+}
+
+// getResourceShareTags returns the tags of the supplied resource share
+// shared with the account, or nil if the resource share isn't visible.
+func (r *Reconciler) getResourceShareTags(
+	ctx context.Context,
+	resourceShareARN string,
+) (map[string]string, error) {
+	resp, err := r.sdkapi.GetResourceShares(
+		ctx,
+		&svcsdk.GetResourceSharesInput{
+			ResourceOwner:     svcsdktypes.ResourceOwnerOtherAccounts,
+			ResourceShareArns: []string{resourceShareARN},
+		},
+	)
+	r.metrics.RecordAPICall("READ_MANY", "GetResourceShares", err)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.ResourceShares) == 0 {
+		return nil, nil
+	}
+	tags := map[string]string{}
+	for _, t := range resp.ResourceShares[0].Tags {
+		if t.Key != nil {
+			tags[*t.Key] = aws.ToString(t.Value)
+		}
+	}
+	return tags, nil
+}
+
+// managedResourceShareARNs returns the ARNs of the resource shares that have
+// a ResourceShareInvitation resource.
+func (r *Reconciler) managedResourceShareARNs(ctx context.Context) (map[string]struct{}, error) {
+	list := &svcapitypes.ResourceShareInvitationList{}
+	if err := r.kc.List(ctx, list); err != nil {
+		return nil, err
+	}
+	arns := make(map[string]struct{}, len(list.Items))
+	for _, item := range list.Items {
+		if item.Spec.ResourceShareARN != nil {
+			arns[*item.Spec.ResourceShareARN] = struct{}{}
+		}
+	}
+	return arns, nil
+}
+
+// setSyncedCondition sets the ResourceSynced condition of the policy from
+// the outcome of the last poll.
+func setSyncedCondition(policy *svcapitypes.InvitationAcceptancePolicy, pollErr error) {
+	var cond *ackv1alpha1.Condition
+	for _, c := range policy.Status.Conditions {
+		if c.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ackv1alpha1.ConditionTypeResourceSynced}
+		policy.Status.Conditions = append(policy.Status.Conditions, cond)
+	}
+	status := corev1.ConditionTrue
+	var message *string
+	if pollErr != nil {
+		status = corev1.ConditionFalse
+		message = aws.String(pollErr.Error())
+	}
+	if cond.Status != status {
+		now := metav1.Now()
+		cond.LastTransitionTime = &now
+	}
+	cond.Status = status
+	cond.Message = message
+}
+
+// pollInterval returns the interval between two polls of the policy.
+func pollInterval(policy *svcapitypes.InvitationAcceptancePolicy) time.Duration {
+	if policy.Spec.PollIntervalSeconds == nil {
+		return defaultPollInterval
+	}
+	return time.Duration(*policy.Spec.PollIntervalSeconds) * time.Second
+}
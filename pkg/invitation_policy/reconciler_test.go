@@ -0,0 +1,362 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package invitation_policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+const (
+	trustedAccount   = "111111111111"
+	untrustedAccount = "222222222222"
+)
+
+// newPolicy returns a policy named name that takes action on the
+// invitations sent by trustedAccount.
+func newPolicy(name string, action string) *svcapitypes.InvitationAcceptancePolicy {
+	return &svcapitypes.InvitationAcceptancePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)},
+		Spec: svcapitypes.InvitationAcceptancePolicySpec{
+			Action:                  aws.String(action),
+			TrustedSenderAccountIDs: aws.StringSlice([]string{trustedAccount}),
+		},
+	}
+}
+
+// newInvitation returns a pending invitation to the resource share named
+// name sent by sender.
+func newInvitation(name string, sender string) svcsdktypes.ResourceShareInvitation {
+	return svcsdktypes.ResourceShareInvitation{
+		ResourceShareInvitationArn: aws.String("arn:aws:ram:us-west-2:" + sender + ":resource-share-invitation/" + name),
+		ResourceShareArn:           aws.String("arn:aws:ram:us-west-2:" + sender + ":resource-share/" + name),
+		ResourceShareName:          aws.String(name),
+		SenderAccountId:            aws.String(sender),
+		Status:                     svcsdktypes.ResourceShareInvitationStatusPending,
+	}
+}
+
+// staticTags returns a tagsFunc returning the supplied tags for every
+// resource share.
+func staticTags(tags map[string]string, err error) tagsFunc {
+	return func(context.Context, string) (map[string]string, error) {
+		return tags, err
+	}
+}
+
+func TestMismatchReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     func(spec *svcapitypes.InvitationAcceptancePolicySpec)
+		invitation svcsdktypes.ResourceShareInvitation
+		tags       tagsFunc
+		wantReason string
+		wantErr    bool
+	}{
+		{
+			name:       "trusted sender",
+			update:     func(spec *svcapitypes.InvitationAcceptancePolicySpec) {},
+			invitation: newInvitation("share", trustedAccount),
+		},
+		{
+			name:       "untrusted sender",
+			update:     func(spec *svcapitypes.InvitationAcceptancePolicySpec) {},
+			invitation: newInvitation("share", untrustedAccount),
+			wantReason: "sender account is not trusted",
+		},
+		{
+			name: "name matching a pattern",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.ResourceShareNamePatterns = aws.StringSlice([]string{"dev-*", "team-?"})
+			},
+			invitation: newInvitation("team-a", trustedAccount),
+		},
+		{
+			name: "name matching no pattern",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.ResourceShareNamePatterns = aws.StringSlice([]string{"dev-*"})
+			},
+			invitation: newInvitation("prod-share", trustedAccount),
+			wantReason: "resource share name matches none of the patterns",
+		},
+		{
+			name: "invalid pattern",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.ResourceShareNamePatterns = aws.StringSlice([]string{"[dev"})
+			},
+			invitation: newInvitation("dev", trustedAccount),
+			wantErr:    true,
+		},
+		{
+			name: "untrusted sender is reported before the name",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.ResourceShareNamePatterns = aws.StringSlice([]string{"dev-*"})
+			},
+			invitation: newInvitation("prod-share", untrustedAccount),
+			wantReason: "sender account is not trusted",
+		},
+		{
+			name: "required tags present",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.RequiredTags = []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}}
+			},
+			invitation: newInvitation("share", trustedAccount),
+			tags:       staticTags(map[string]string{"team": "a", "env": "dev"}, nil),
+		},
+		{
+			name: "required tag with another value",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.RequiredTags = []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}}
+			},
+			invitation: newInvitation("share", trustedAccount),
+			tags:       staticTags(map[string]string{"team": "b"}, nil),
+			wantReason: `resource share is missing tag "team"`,
+		},
+		{
+			name: "required tag missing",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.RequiredTags = []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}}
+			},
+			invitation: newInvitation("share", trustedAccount),
+			tags:       staticTags(map[string]string{}, nil),
+			wantReason: `resource share is missing tag "team"`,
+		},
+		{
+			name: "resource share not visible",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.RequiredTags = []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}}
+			},
+			invitation: newInvitation("share", trustedAccount),
+			tags:       staticTags(nil, nil),
+			wantReason: "resource share tags can't be read",
+		},
+		{
+			name: "reading tags fails",
+			update: func(spec *svcapitypes.InvitationAcceptancePolicySpec) {
+				spec.RequiredTags = []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}}
+			},
+			invitation: newInvitation("share", trustedAccount),
+			tags:       staticTags(nil, errors.New("throttled")),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := newPolicy("policy", "ACCEPT")
+			tt.update(&policy.Spec)
+			tags := tt.tags
+			if tags == nil {
+				tags = staticTags(nil, errors.New("tags must not be read"))
+			}
+			reason, err := mismatchReason(context.Background(), policy, tt.invitation, tags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mismatchReason() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if reason != tt.wantReason {
+				t.Errorf("mismatchReason() = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestPrecedes(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *svcapitypes.InvitationAcceptancePolicy
+		b    *svcapitypes.InvitationAcceptancePolicy
+		want bool
+	}{
+		{
+			name: "reject precedes accept",
+			a:    newPolicy("z", actionReject),
+			b:    newPolicy("a", "ACCEPT"),
+			want: true,
+		},
+		{
+			name: "accept doesn't precede reject",
+			a:    newPolicy("a", "ACCEPT"),
+			b:    newPolicy("z", actionReject),
+		},
+		{
+			name: "same action, name sorting first",
+			a:    newPolicy("a", "ACCEPT"),
+			b:    newPolicy("b", "ACCEPT"),
+			want: true,
+		},
+		{
+			name: "same action, name sorting last",
+			a:    newPolicy("b", actionReject),
+			b:    newPolicy("a", actionReject),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := precedes(tt.a, tt.b); got != tt.want {
+				t.Errorf("precedes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrecedingPolicy(t *testing.T) {
+	untrusting := newPolicy("a-untrusting", actionReject)
+	untrusting.Spec.TrustedSenderAccountIDs = aws.StringSlice([]string{untrustedAccount})
+	tests := []struct {
+		name     string
+		policy   *svcapitypes.InvitationAcceptancePolicy
+		policies []svcapitypes.InvitationAcceptancePolicy
+		want     string
+	}{
+		{
+			name:   "no other policy",
+			policy: newPolicy("accept", "ACCEPT"),
+		},
+		{
+			name:     "matching reject policy precedes accept policy",
+			policy:   newPolicy("accept", "ACCEPT"),
+			policies: []svcapitypes.InvitationAcceptancePolicy{*newPolicy("reject", actionReject)},
+			want:     "reject",
+		},
+		{
+			name:     "matching accept policy doesn't precede reject policy",
+			policy:   newPolicy("reject", actionReject),
+			policies: []svcapitypes.InvitationAcceptancePolicy{*newPolicy("accept", "ACCEPT")},
+		},
+		{
+			name:     "reject policy not matching doesn't precede",
+			policy:   newPolicy("accept", "ACCEPT"),
+			policies: []svcapitypes.InvitationAcceptancePolicy{*untrusting},
+		},
+		{
+			name:   "first matching policy is returned",
+			policy: newPolicy("c", "ACCEPT"),
+			policies: []svcapitypes.InvitationAcceptancePolicy{
+				*untrusting, *newPolicy("b", actionReject), *newPolicy("d", actionReject),
+			},
+			want: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := precedingPolicy(
+				context.Background(), tt.policy, tt.policies,
+				newInvitation("share", trustedAccount), staticTags(nil, nil),
+			)
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tt.want {
+				t.Errorf("precedingPolicy() = %q, want %q", name, tt.want)
+			}
+		})
+	}
+}
+
+func TestRememberIgnored(t *testing.T) {
+	r := &Reconciler{ignored: map[types.UID]map[string]string{}}
+	policy := newPolicy("policy", "ACCEPT")
+	other := newPolicy("other", "ACCEPT")
+	steps := []struct {
+		name       string
+		policy     *svcapitypes.InvitationAcceptancePolicy
+		invitation string
+		reason     string
+		want       bool
+	}{
+		{name: "first time", policy: policy, invitation: "a", reason: "untrusted", want: true},
+		{name: "same reason", policy: policy, invitation: "a", reason: "untrusted"},
+		{name: "another reason", policy: policy, invitation: "a", reason: "name", want: true},
+		{name: "another invitation", policy: policy, invitation: "b", reason: "name", want: true},
+		{name: "another policy", policy: other, invitation: "a", reason: "name", want: true},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			if got := r.rememberIgnored(step.policy, step.invitation, step.reason); got != step.want {
+				t.Errorf("rememberIgnored() = %v, want %v", got, step.want)
+			}
+		})
+	}
+
+	r.forgetIgnored(policy, map[string]struct{}{"b": {}})
+	if !r.rememberIgnored(policy, "a", "name") {
+		t.Errorf("rememberIgnored() = false after the invitation stopped being pending, want true")
+	}
+	if r.rememberIgnored(policy, "b", "name") {
+		t.Errorf("rememberIgnored() = true for an invitation still pending, want false")
+	}
+	if r.rememberIgnored(other, "a", "name") {
+		t.Errorf("rememberIgnored() = true for an invitation of another policy, want false")
+	}
+}
+
+func TestEvaluateIgnored(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{recorder: recorder, ignored: map[types.UID]map[string]string{}}
+	policy := newPolicy("evaluate-ignored", "ACCEPT")
+	inv := newInvitation("share", untrustedAccount)
+	counter := invitationsTotal.WithLabelValues(policy.Name, outcomeIgnored)
+
+	for i := 0; i < 3; i++ {
+		if err := r.evaluate(context.Background(), policy, nil, inv, staticTags(nil, nil)); err != nil {
+			t.Fatalf("evaluate() error = %v", err)
+		}
+	}
+	if got := testutil.ToFloat64(counter); got != 1 {
+		t.Errorf("ignored invitations = %v, want 1", got)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("recorded %d events, want 1", got)
+	}
+}
+
+func TestRecordInvitation(t *testing.T) {
+	tests := []struct {
+		policy  string
+		outcome string
+	}{
+		{policy: "record-a", outcome: outcomeAccepted},
+		{policy: "record-a", outcome: outcomeRejected},
+		{policy: "record-b", outcome: outcomeAccepted},
+		{policy: "record-b", outcome: outcomeIgnored},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy+"/"+tt.outcome, func(t *testing.T) {
+			before := make([]float64, len(tests))
+			for i, c := range tests {
+				before[i] = testutil.ToFloat64(invitationsTotal.WithLabelValues(c.policy, c.outcome))
+			}
+			recordInvitation(tt.policy, tt.outcome)
+			for i, c := range tests {
+				want := before[i]
+				if c == tt {
+					want++
+				}
+				if got := testutil.ToFloat64(invitationsTotal.WithLabelValues(c.policy, c.outcome)); got != want {
+					t.Errorf("counter {policy=%q, outcome=%q} = %v, want %v", c.policy, c.outcome, got, want)
+				}
+			}
+		})
+	}
+}
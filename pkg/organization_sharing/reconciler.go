@@ -0,0 +1,177 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package organization_sharing contains the reconciler for the
+// OrganizationSharing resource. Like an InvitationAcceptancePolicy, it
+// doesn't map to an AWS resource: it enables sharing with AWS Organizations
+// for the controller's AWS account and reports whether sharing is enabled,
+// which the ResourceShares sharing with an organization or an organizational
+// unit read from its status through CurrentState.
+package organization_sharing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=ram.services.k8s.aws,resources=organizationsharings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ram.services.k8s.aws,resources=organizationsharings/status,verbs=get;update;patch
+
+const (
+	controllerName = "organizationsharing"
+
+	defaultResyncPeriod = time.Hour
+)
+
+// Reconciler enables sharing with AWS Organizations for the account of the
+// controller and reports its state in the OrganizationSharing resource.
+type Reconciler struct {
+	kc     client.Client
+	log    logr.Logger
+	sdkapi *svcsdk.Client
+}
+
+// SetupWithManager creates a Reconciler that uses the AWS account and
+// region the service controller is configured with, and registers it with
+// the supplied manager.
+func SetupWithManager(
+	ctx context.Context,
+	mgr ctrlrt.Manager,
+	sc acktypes.ServiceController,
+	cfg ackcfg.Config,
+) error {
+	gvk := svcapitypes.GroupVersion.WithKind("OrganizationSharing")
+	clientcfg, err := sc.NewAWSConfig(
+		ctx, ackv1alpha1.AWSRegion(cfg.Region), &cfg.EndpointURL, "", gvk, nil,
+	)
+	if err != nil {
+		return err
+	}
+	r := &Reconciler{
+		kc:     mgr.GetClient(),
+		log:    ctrlrt.Log.WithName("ackrt").WithName(controllerName),
+		sdkapi: svcsdk.NewFromConfig(clientcfg),
+	}
+	return ctrlrt.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		// Status updates don't change the generation, which keeps them from
+		// triggering a sync outside of the resync period.
+		For(
+			&svcapitypes.OrganizationSharing{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Complete(r)
+}
+
+// Reconcile enables sharing with AWS Organizations, records whether it is
+// enabled and requeues the resource after its resync period.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrlrt.Request) (ctrlrt.Result, error) {
+	sharing := &svcapitypes.OrganizationSharing{}
+	if err := r.kc.Get(ctx, req.NamespacedName, sharing); err != nil {
+		return ctrlrt.Result{}, client.IgnoreNotFound(err)
+	}
+	if !sharing.DeletionTimestamp.IsZero() {
+		return ctrlrt.Result{}, nil
+	}
+	log := r.log.WithValues("organizationSharing", sharing.Name)
+
+	enabled, syncErr := r.enable(ctx)
+	if syncErr != nil {
+		log.Error(syncErr, "failed to enable sharing with AWS Organizations")
+	}
+
+	patch := client.MergeFrom(sharing.DeepCopy())
+	if enabled != nil {
+		sharing.Status.SharingEnabled = enabled
+	}
+	if syncErr == nil {
+		now := metav1.Now()
+		sharing.Status.LastSyncTime = &now
+	}
+	setSyncedCondition(sharing, syncErr)
+	if err := r.kc.Status().Patch(ctx, sharing, patch); err != nil {
+		return ctrlrt.Result{}, err
+	}
+	return ctrlrt.Result{RequeueAfter: resyncPeriod(sharing)}, nil
+}
+
+// enable enables sharing with AWS Organizations. It returns whether sharing
+// is enabled, or nil if that can't be told from the outcome of the call.
+func (r *Reconciler) enable(ctx context.Context) (*bool, error) {
+	resp, err := r.sdkapi.EnableSharingWithAwsOrganization(
+		ctx, &svcsdk.EnableSharingWithAwsOrganizationInput{},
+	)
+	if err != nil {
+		// RAM only lets the management account of the organization enable
+		// sharing, any other account is told the operation isn't permitted.
+		var notPermitted *svcsdktypes.OperationNotPermittedException
+		if errors.As(err, &notPermitted) {
+			return aws.Bool(false), err
+		}
+		return nil, err
+	}
+	return aws.Bool(aws.ToBool(resp.ReturnValue)), nil
+}
+
+// setSyncedCondition sets the ResourceSynced condition of the resource from
+// the outcome of the last attempt to enable sharing.
+func setSyncedCondition(sharing *svcapitypes.OrganizationSharing, syncErr error) {
+	var cond *ackv1alpha1.Condition
+	for _, c := range sharing.Status.Conditions {
+		if c.Type == ackv1alpha1.ConditionTypeResourceSynced {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ackv1alpha1.ConditionTypeResourceSynced}
+		sharing.Status.Conditions = append(sharing.Status.Conditions, cond)
+	}
+	status := corev1.ConditionTrue
+	var message *string
+	if syncErr != nil {
+		status = corev1.ConditionFalse
+		message = aws.String(syncErr.Error())
+	}
+	if cond.Status != status {
+		now := metav1.Now()
+		cond.LastTransitionTime = &now
+	}
+	cond.Status = status
+	cond.Message = message
+}
+
+// resyncPeriod returns the interval between two syncs of the resource.
+func resyncPeriod(sharing *svcapitypes.OrganizationSharing) time.Duration {
+	if sharing.Spec.ResyncPeriodSeconds == nil {
+		return defaultResyncPeriod
+	}
+	return time.Duration(*sharing.Spec.ResyncPeriodSeconds) * time.Second
+}
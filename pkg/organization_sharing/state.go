@@ -0,0 +1,77 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package organization_sharing
+
+import (
+	"context"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+// ResourceName is the name of the OrganizationSharing resource, the only
+// name the resource is allowed to have.
+const ResourceName = "default"
+
+// State is the state of sharing with AWS Organizations reported by the
+// OrganizationSharing resource.
+type State struct {
+	// Known is false when there is no OrganizationSharing resource, or it
+	// hasn't been able to tell whether sharing is enabled yet.
+	Known bool
+	// Enabled is whether sharing with AWS Organizations is enabled.
+	Enabled bool
+	// Name is the name of the OrganizationSharing resource.
+	Name string
+	// Message is the message of the last error met enabling sharing.
+	Message string
+}
+
+// CurrentState returns the state of sharing with AWS Organizations reported
+// in the status of the OrganizationSharing resource.
+func CurrentState(ctx context.Context, reader client.Reader) (State, error) {
+	sharing := &svcapitypes.OrganizationSharing{}
+	err := reader.Get(ctx, types.NamespacedName{Name: ResourceName}, sharing)
+	if apierrors.IsNotFound(err) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+	if !sharing.DeletionTimestamp.IsZero() {
+		return State{}, nil
+	}
+	return stateOf(sharing), nil
+}
+
+// stateOf returns the state of sharing reported by the supplied resource.
+func stateOf(sharing *svcapitypes.OrganizationSharing) State {
+	s := State{
+		Known:   sharing.Status.SharingEnabled != nil,
+		Enabled: aws.ToBool(sharing.Status.SharingEnabled),
+		Name:    sharing.Name,
+	}
+	for _, c := range sharing.Status.Conditions {
+		if c.Type == ackv1alpha1.ConditionTypeResourceSynced && c.Status == corev1.ConditionFalse {
+			s.Message = aws.ToString(c.Message)
+		}
+	}
+	return s
+}
@@ -0,0 +1,312 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package permission
+
+import (
+	"path"
+	"strings"
+)
+
+// resourceTypeActions maps the resource types that can be shared with RAM to
+// the actions that their AWS managed permissions grant. Resource types and
+// actions are lower case, as RAM doesn't treat them as case sensitive.
+//
+// The catalog mirrors the policy templates of the AWS managed permissions,
+// and needs to be refreshed when RAM supports new resource types or actions:
+//
+//	aws ram list-permissions --permission-type AWS_MANAGED
+//	aws ram get-permission --permission-arn <arn>
+var resourceTypeActions = map[string][]string{
+	"acm-pca:certificateauthority": {
+		"acm-pca:describecertificateauthority",
+		"acm-pca:getcertificate",
+		"acm-pca:getcertificateauthoritycertificate",
+		"acm-pca:issuecertificate",
+		"acm-pca:listpermissions",
+		"acm-pca:listtags",
+		"acm-pca:revokecertificate",
+	},
+	"appmesh:mesh": {
+		"appmesh:creategatewayroute",
+		"appmesh:createroute",
+		"appmesh:createvirtualgateway",
+		"appmesh:createvirtualnode",
+		"appmesh:createvirtualrouter",
+		"appmesh:createvirtualservice",
+		"appmesh:deletegatewayroute",
+		"appmesh:deleteroute",
+		"appmesh:deletevirtualgateway",
+		"appmesh:deletevirtualnode",
+		"appmesh:deletevirtualrouter",
+		"appmesh:deletevirtualservice",
+		"appmesh:describegatewayroute",
+		"appmesh:describemesh",
+		"appmesh:describeroute",
+		"appmesh:describevirtualgateway",
+		"appmesh:describevirtualnode",
+		"appmesh:describevirtualrouter",
+		"appmesh:describevirtualservice",
+		"appmesh:listgatewayroutes",
+		"appmesh:listroutes",
+		"appmesh:listtagsforresource",
+		"appmesh:listvirtualgateways",
+		"appmesh:listvirtualnodes",
+		"appmesh:listvirtualrouters",
+		"appmesh:listvirtualservices",
+		"appmesh:tagresource",
+		"appmesh:untagresource",
+		"appmesh:updategatewayroute",
+		"appmesh:updateroute",
+		"appmesh:updatevirtualgateway",
+		"appmesh:updatevirtualnode",
+		"appmesh:updatevirtualrouter",
+		"appmesh:updatevirtualservice",
+	},
+	"codebuild:project": {
+		"codebuild:batchgetbuildbatches",
+		"codebuild:batchgetbuilds",
+		"codebuild:batchgetprojects",
+		"codebuild:listbuildbatchesforproject",
+		"codebuild:listbuildsforproject",
+	},
+	"codebuild:reportgroup": {
+		"codebuild:batchgetreportgroups",
+		"codebuild:batchgetreports",
+		"codebuild:describecodecoverages",
+		"codebuild:describetestcases",
+		"codebuild:getreportgrouptrend",
+		"codebuild:listreportsforreportgroup",
+	},
+	"ec2:capacityreservation": {
+		"ec2:createfleet",
+		"ec2:describecapacityreservations",
+		"ec2:runinstances",
+	},
+	"ec2:coippool": {
+		"ec2:allocateaddress",
+		"ec2:describecoippools",
+		"ec2:getcoippoolusage",
+	},
+	"ec2:dedicatedhost": {
+		"ec2:describehosts",
+		"ec2:modifyinstanceplacement",
+		"ec2:runinstances",
+	},
+	"ec2:ipampool": {
+		"ec2:allocateipampoolcidr",
+		"ec2:associatesubnetcidrblock",
+		"ec2:associatevpccidrblock",
+		"ec2:createsubnet",
+		"ec2:createvpc",
+		"ec2:describeipampools",
+		"ec2:getipampoolallocations",
+		"ec2:getipampoolcidrs",
+		"ec2:releaseipampoolallocation",
+	},
+	"ec2:localgatewayroutetable": {
+		"ec2:createlocalgatewayroute",
+		"ec2:deletelocalgatewayroute",
+		"ec2:describelocalgatewayroutetables",
+		"ec2:searchlocalgatewayroutes",
+	},
+	"ec2:prefixlist": {
+		"ec2:authorizesecuritygroupegress",
+		"ec2:authorizesecuritygroupingress",
+		"ec2:createroute",
+		"ec2:describemanagedprefixlists",
+		"ec2:getmanagedprefixlistassociations",
+		"ec2:getmanagedprefixlistentries",
+		"ec2:replaceroute",
+		"ec2:revokesecuritygroupegress",
+		"ec2:revokesecuritygroupingress",
+	},
+	"ec2:subnet": {
+		"ec2:createnetworkinterface",
+		"ec2:createtags",
+		"ec2:createvpcendpoint",
+		"ec2:describesubnets",
+		"ec2:runinstances",
+	},
+	"ec2:trafficmirrortarget": {
+		"ec2:createtrafficmirrorsession",
+		"ec2:describetrafficmirrortargets",
+	},
+	"ec2:transitgateway": {
+		"ec2:createtransitgatewayvpcattachment",
+		"ec2:createvpnconnection",
+		"ec2:describetransitgateways",
+	},
+	"ec2:transitgatewaymulticastdomain": {
+		"ec2:associatetransitgatewaymulticastdomain",
+		"ec2:describetransitgatewaymulticastdomains",
+		"ec2:disassociatetransitgatewaymulticastdomain",
+		"ec2:gettransitgatewaymulticastdomainassociations",
+	},
+	"glue:catalog": {
+		"glue:batchgetpartition",
+		"glue:getdatabase",
+		"glue:getdatabases",
+		"glue:getpartition",
+		"glue:getpartitions",
+		"glue:gettable",
+		"glue:gettables",
+		"glue:searchtables",
+	},
+	"glue:database": {
+		"glue:batchgetpartition",
+		"glue:getdatabase",
+		"glue:getpartition",
+		"glue:getpartitions",
+		"glue:gettable",
+		"glue:gettables",
+		"glue:searchtables",
+	},
+	"glue:table": {
+		"glue:batchgetpartition",
+		"glue:getpartition",
+		"glue:getpartitions",
+		"glue:gettable",
+		"glue:gettables",
+		"glue:searchtables",
+	},
+	"imagebuilder:component": {
+		"imagebuilder:getcomponent",
+		"imagebuilder:listcomponents",
+	},
+	"imagebuilder:containerrecipe": {
+		"imagebuilder:getcontainerrecipe",
+		"imagebuilder:listcontainerrecipes",
+	},
+	"imagebuilder:image": {
+		"imagebuilder:getimage",
+		"imagebuilder:listimagebuildversions",
+		"imagebuilder:listimages",
+	},
+	"imagebuilder:imagerecipe": {
+		"imagebuilder:getimagerecipe",
+		"imagebuilder:listimagerecipes",
+	},
+	"license-manager:licenseconfiguration": {
+		"license-manager:getlicenseconfiguration",
+		"license-manager:listlicenseconfigurations",
+		"license-manager:listlicensespecificationsforresource",
+		"license-manager:updatelicensespecificationsforresource",
+	},
+	"network-firewall:firewallpolicy": {
+		"network-firewall:associatefirewallpolicy",
+		"network-firewall:describefirewallpolicy",
+		"network-firewall:listfirewallpolicies",
+	},
+	"network-firewall:statefulrulegroup": {
+		"network-firewall:createfirewallpolicy",
+		"network-firewall:describerulegroup",
+		"network-firewall:listrulegroups",
+		"network-firewall:updatefirewallpolicy",
+	},
+	"network-firewall:statelessrulegroup": {
+		"network-firewall:createfirewallpolicy",
+		"network-firewall:describerulegroup",
+		"network-firewall:listrulegroups",
+		"network-firewall:updatefirewallpolicy",
+	},
+	"outposts:outpost": {
+		"outposts:getoutpost",
+		"outposts:getoutpostinstancetypes",
+		"outposts:listoutposts",
+	},
+	"outposts:site": {
+		"outposts:getsite",
+		"outposts:listsites",
+	},
+	"rds:cluster": {
+		"rds:createdbclusterendpoint",
+		"rds:describedbclusters",
+		"rds:restoredbclustertopointintime",
+	},
+	"resource-groups:group": {
+		"resource-groups:getgroup",
+		"resource-groups:listgroupresources",
+	},
+	"route53resolver:firewallrulegroup": {
+		"route53resolver:associatefirewallrulegroup",
+		"route53resolver:disassociatefirewallrulegroup",
+		"route53resolver:getfirewallrulegroup",
+		"route53resolver:listfirewallrules",
+	},
+	"route53resolver:resolverquerylogconfig": {
+		"route53resolver:associateresolverquerylogconfig",
+		"route53resolver:disassociateresolverquerylogconfig",
+		"route53resolver:getresolverquerylogconfig",
+		"route53resolver:listresolverquerylogconfigassociations",
+		"route53resolver:listresolverquerylogconfigs",
+	},
+	"route53resolver:resolverrule": {
+		"route53resolver:associateresolverrule",
+		"route53resolver:disassociateresolverrule",
+		"route53resolver:getresolverrule",
+		"route53resolver:listresolverrules",
+	},
+	"s3-outposts:outpost": {
+		"s3-outposts:createendpoint",
+		"s3-outposts:deleteendpoint",
+		"s3-outposts:listendpoints",
+		"s3-outposts:listoutpostswiths3",
+	},
+	"ssm-contacts:contact": {
+		"ssm-contacts:getcontact",
+		"ssm-contacts:listcontacts",
+		"ssm-contacts:listcontactchannels",
+		"ssm-contacts:startengagement",
+	},
+	"ssm-incidents:responseplan": {
+		"ssm-incidents:getincidentrecord",
+		"ssm-incidents:getresponseplan",
+		"ssm-incidents:listincidentrecords",
+		"ssm-incidents:listrelateditems",
+		"ssm-incidents:listtimelineevents",
+		"ssm-incidents:updateincidentrecord",
+		"ssm-incidents:updaterelateditems",
+	},
+	"vpc-lattice:service": {
+		"vpc-lattice:createservicenetworkserviceassociation",
+		"vpc-lattice:getservice",
+		"vpc-lattice:listservices",
+	},
+	"vpc-lattice:servicenetwork": {
+		"vpc-lattice:createservicenetworkserviceassociation",
+		"vpc-lattice:createservicenetworkvpcassociation",
+		"vpc-lattice:getservicenetwork",
+		"vpc-lattice:listservicenetworks",
+	},
+}
+
+// supportedActions returns the actions that a permission for the supplied
+// resource type may grant, and false if the catalog doesn't know the
+// resource type.
+func supportedActions(resourceType string) ([]string, bool) {
+	actions, ok := resourceTypeActions[strings.ToLower(resourceType)]
+	return actions, ok
+}
+
+// actionSupported returns whether the supplied action, which may contain
+// the * and ? wildcards, matches at least one of the supplied actions.
+func actionSupported(action string, supported []string) bool {
+	pattern := strings.ToLower(action)
+	for _, candidate := range supported {
+		if ok, err := path.Match(pattern, candidate); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
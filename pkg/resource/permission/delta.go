@@ -40,6 +40,7 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
+	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.Name, b.ko.Spec.Name) {
 		delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
@@ -48,11 +49,18 @@ func newResourceDelta(
 			delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 		}
 	}
-	if ackcompare.HasNilDifference(a.ko.Spec.PolicyTemplate, b.ko.Spec.PolicyTemplate) {
-		delta.Add("Spec.PolicyTemplate", a.ko.Spec.PolicyTemplate, b.ko.Spec.PolicyTemplate)
-	} else if a.ko.Spec.PolicyTemplate != nil && b.ko.Spec.PolicyTemplate != nil {
-		if *a.ko.Spec.PolicyTemplate != *b.ko.Spec.PolicyTemplate {
-			delta.Add("Spec.PolicyTemplate", a.ko.Spec.PolicyTemplate, b.ko.Spec.PolicyTemplate)
+	if ackcompare.HasNilDifference(a.ko.Spec.PinnedVersion, b.ko.Spec.PinnedVersion) {
+		delta.Add("Spec.PinnedVersion", a.ko.Spec.PinnedVersion, b.ko.Spec.PinnedVersion)
+	} else if a.ko.Spec.PinnedVersion != nil && b.ko.Spec.PinnedVersion != nil {
+		if *a.ko.Spec.PinnedVersion != *b.ko.Spec.PinnedVersion {
+			delta.Add("Spec.PinnedVersion", a.ko.Spec.PinnedVersion, b.ko.Spec.PinnedVersion)
+		}
+	}
+	if ackcompare.HasNilDifference(a.ko.Spec.ReplacePermissionAssociations, b.ko.Spec.ReplacePermissionAssociations) {
+		delta.Add("Spec.ReplacePermissionAssociations", a.ko.Spec.ReplacePermissionAssociations, b.ko.Spec.ReplacePermissionAssociations)
+	} else if a.ko.Spec.ReplacePermissionAssociations != nil && b.ko.Spec.ReplacePermissionAssociations != nil {
+		if *a.ko.Spec.ReplacePermissionAssociations != *b.ko.Spec.ReplacePermissionAssociations {
+			delta.Add("Spec.ReplacePermissionAssociations", a.ko.Spec.ReplacePermissionAssociations, b.ko.Spec.ReplacePermissionAssociations)
 		}
 	}
 	if ackcompare.HasNilDifference(a.ko.Spec.ResourceType, b.ko.Spec.ResourceType) {
@@ -62,6 +70,13 @@ func newResourceDelta(
 			delta.Add("Spec.ResourceType", a.ko.Spec.ResourceType, b.ko.Spec.ResourceType)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.RetainedVersions, b.ko.Spec.RetainedVersions) {
+		delta.Add("Spec.RetainedVersions", a.ko.Spec.RetainedVersions, b.ko.Spec.RetainedVersions)
+	} else if a.ko.Spec.RetainedVersions != nil && b.ko.Spec.RetainedVersions != nil {
+		if *a.ko.Spec.RetainedVersions != *b.ko.Spec.RetainedVersions {
+			delta.Add("Spec.RetainedVersions", a.ko.Spec.RetainedVersions, b.ko.Spec.RetainedVersions)
+		}
+	}
 	desiredACKTags, _ := convertToOrderedACKTags(a.ko.Spec.Tags)
 	latestACKTags, _ := convertToOrderedACKTags(b.ko.Spec.Tags)
 	if !ackcompare.MapStringStringEqual(desiredACKTags, latestACKTags) {
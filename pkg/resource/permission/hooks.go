@@ -15,10 +15,13 @@ package permission
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strconv"
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
@@ -27,18 +30,115 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+	"github.com/aws-controllers-k8s/ram-controller/pkg/idempotency"
 )
 
 const (
 	StatusAttachable = "ATTACHABLE"
+
+	// reasonPermissionUnattachable, reasonPermissionDeleting and
+	// reasonDefaultVersionPending are the reasons of the ResourceSynced
+	// condition while the permission isn't ready to be used.
+	reasonPermissionUnattachable = "PermissionUnattachable"
+	reasonPermissionDeleting     = "PermissionDeleting"
+	reasonDefaultVersionPending  = "DefaultVersionPending"
+
+	// defaultRetainedVersions is the number of versions kept when
+	// Spec.RetainedVersions isn't set.
+	defaultRetainedVersions = 1
+	// maxPermissionVersions is the number of versions RAM allows a customer
+	// managed permission to have.
+	maxPermissionVersions = 5
 )
 
+// listPageSize returns the MaxResults of the RAM list calls.
+func listPageSize() *int32 {
+	return aws.Int32(svcconfig.ListPageSize)
+}
+
+// listLimitExceeded returns the terminal error reported when the supplied
+// RAM list operation has more pages than the controller is allowed to read.
+func listLimitExceeded(operation string) error {
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"%s returned more than %d pages of %d items, increase --%s to manage this permission",
+		operation, svcconfig.ListMaxPages, svcconfig.ListPageSize,
+		svcconfig.FlagListMaxPages,
+	))
+}
+
+// clientToken returns the client token of the supplied call made on behalf
+// of the resource.
+func clientToken(r *resource, operation string, params ...[]string) *string {
+	return idempotency.ClientToken(r.ko, r.ko.Status.ClientTokenNonce, operation, params...)
+}
+
+// bumpClientTokenNonce bumps the nonce of the client tokens of the resource
+// once the calls made with the current one succeeded.
+func bumpClientTokenNonce(ko *svcapitypes.Permission) {
+	ko.Status.ClientTokenNonce = idempotency.NextNonce(ko.Status.ClientTokenNonce)
+}
+
+// customPreCompare compares the policy templates semantically rather than
+// byte for byte, so that RAM's normalized echo of the desired template
+// doesn't create a new version of the permission on every reconcile. It also
+// reports Spec.RetainedVersions as different while the permission has more
+// versions than it retains, as RAM doesn't return the field.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	// Spec.PolicyStatements is compared through the template it renders to,
+	// against the template RAM returned.
+	desiredTemplate, err := policyTemplate(a)
+	if err != nil {
+		delta.Add("Spec.PolicyTemplate", a.ko.Spec.PolicyTemplate, b.ko.Spec.PolicyTemplate)
+		return
+	}
+	latestTemplate := b.ko.Spec.PolicyTemplate
+	if b.ko.Spec.PolicyStatements != nil {
+		latestTemplate = b.ko.Status.ObservedPolicyTemplate
+	}
+	if ackcompare.HasNilDifference(desiredTemplate, latestTemplate) {
+		delta.Add("Spec.PolicyTemplate", desiredTemplate, latestTemplate)
+	} else if desiredTemplate != nil && latestTemplate != nil {
+		if !policyTemplatesEqual(*desiredTemplate, *latestTemplate) {
+			delta.Add("Spec.PolicyTemplate", desiredTemplate, latestTemplate)
+		}
+	}
+	if hasExcessVersions(a, b.ko.Status.Versions) {
+		delta.Add("Spec.RetainedVersions", a.ko.Spec.RetainedVersions, b.ko.Spec.RetainedVersions)
+	}
+}
+
+// hasExcessVersions returns true if the supplied versions of the permission
+// outnumber the versions it retains and at least one of them can be deleted.
+func hasExcessVersions(r *resource, versions []*svcapitypes.ResourceSharePermissionSummary) bool {
+	if len(versions) <= retainedVersions(r.ko) {
+		return false
+	}
+	for _, v := range versions {
+		if !aws.ToBool(v.DefaultVersion) && !isPinnedVersion(r, v.Version) &&
+			!isReplacedVersion(r, v.Version) {
+			return true
+		}
+	}
+	return false
+}
+
 func (rm *resourceManager) customUpdatePermission(
 	ctx context.Context,
 	desired *resource,
 	latest *resource,
 	delta *ackcompare.Delta,
 ) (*resource, error) {
+	if err := validatePolicy(desired); err != nil {
+		return nil, err
+	}
+	if err := validateRetainedVersions(desired); err != nil {
+		return nil, err
+	}
 	ko := desired.ko.DeepCopy()
 
 	rm.setStatusDefaults(ko)
@@ -47,22 +147,85 @@ func (rm *resourceManager) customUpdatePermission(
 		if err := rm.syncTags(ctx, desired, latest); err != nil {
 			return nil, err
 		}
-		ackcondition.SetSynced(&resource{ko}, corev1.ConditionTrue, nil, nil)
 	}
 
 	if delta.DifferentAt("Spec.PolicyTemplate") {
-		err := rm.updatePermission(ctx, desired)
+		err := rm.updatePermission(ctx, &resource{ko})
 		if err != nil {
 			return nil, err
 		}
-		// resource takes time to retrieve the latest version. Syncing after
-		// 30 seconds gets the job done
-		ackcondition.SetSynced(&resource{ko}, corev1.ConditionFalse, nil, nil)
+		bumpClientTokenNonce(ko)
+		// The new version only shows up in Status.Versions once the
+		// permission is read again. A pinned version stays the default
+		// version, the new one is only created.
+		msg := fmt.Sprintf(
+			"waiting for version %s to become the default version of the permission",
+			aws.ToString(ko.Status.Version),
+		)
+		if ko.Spec.PinnedVersion != nil {
+			msg = fmt.Sprintf(
+				"created version %s of the permission, pinned version %d stays the default version",
+				aws.ToString(ko.Status.Version), *ko.Spec.PinnedVersion,
+			)
+		}
+		ackcondition.SetSynced(&resource{ko}, corev1.ConditionFalse, &msg, aws.String(reasonDefaultVersionPending))
+		return &resource{ko}, nil
+	} else if delta.DifferentAt("Spec.PinnedVersion") {
+		err := rm.setDefaultPermissionVersion(ctx, desired, latestVersion(latest.ko.Status.Versions))
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Creating a version already deletes the versions beyond the retained
+	// ones, otherwise they're deleted here, e.g. after Spec.RetainedVersions
+	// was lowered.
+	if !delta.DifferentAt("Spec.PolicyTemplate") && delta.DifferentAt("Spec.RetainedVersions") {
+		if err := rm.deleteOldPermissionVersions(ctx, &resource{ko}, retainedVersions(ko)); err != nil {
+			return nil, err
+		}
 	}
 
+	setPermissionReadiness(desired, ko)
 	return &resource{ko}, nil
 }
 
+// setPermissionReadiness reports the permission as not synced, along with
+// the reason why, until it is ATTACHABLE and its default version is the
+// desired one: Spec.PinnedVersion when a version is pinned, the most recent
+// version otherwise. Once both hold, IsSynced reports the permission as
+// synced.
+func setPermissionReadiness(desired *resource, ko *svcapitypes.Permission) {
+	r := &resource{ko}
+	var reason, msg string
+	switch {
+	case aws.ToString(ko.Status.Status) == string(svcapitypes.PermissionStatus_SDK_DELETING):
+		reason = reasonPermissionDeleting
+		msg = "the permission is being deleted"
+	case !permissionAttachable(r):
+		reason = reasonPermissionUnattachable
+		msg = fmt.Sprintf(
+			"the default version of the permission is %s, it can't be attached to resource shares",
+			aws.ToString(ko.Status.Status),
+		)
+	default:
+		want := latestVersion(ko.Status.Versions)
+		if desired.ko.Spec.PinnedVersion != nil {
+			want = aws.String(strconv.FormatInt(*desired.ko.Spec.PinnedVersion, 10))
+		}
+		if want != nil && aws.ToString(ko.Status.Version) != *want {
+			reason = reasonDefaultVersionPending
+			msg = fmt.Sprintf(
+				"waiting for version %s to become the default version of the permission, the current default version is %s",
+				*want, aws.ToString(ko.Status.Version),
+			)
+		}
+	}
+	if reason == "" {
+		return
+	}
+	ackcondition.SetSynced(r, corev1.ConditionFalse, &msg, &reason)
+}
+
 func permissionAttachable(r *resource) bool {
 	if r.ko.Status.Status == nil {
 		return false
@@ -71,19 +234,16 @@ func permissionAttachable(r *resource) bool {
 	return ps == StatusAttachable
 }
 
-// In this function we decide to create a permission version
-// when there's an update to the Policy statement.
-// If this operation is successful, we will delete the current
-// PermissionVersion, and ensure we only have one PermissionVeriosn
-// at all times
-//
-// We decided to take this approach, because two PermissionVersions
-// with the same policyTemplate are not allowed to coexist
+// updatePermission creates a new version of the permission when there's an
+// update to the policy template. RAM makes the new version the default
+// version, unless Spec.PinnedVersion asks for another one. Afterwards only
+// the Spec.RetainedVersions most recent versions are kept, along with the
+// default version, so that an earlier policy template can be rolled back to
+// by pinning its version.
 //
-// Another approach could have been retrieving all PermissionVersions
-// and comparing the policyTemplates, and only updating the version.
-// We did not take this approach because it would have take more
-// API calls, and comparing PolicyTemplates would require more work.
+// Two PermissionVersions with the same policyTemplate are not allowed to
+// coexist, so rolling back is done by pinning the earlier version rather
+// than by setting the earlier policy template again.
 func (rm *resourceManager) updatePermission(
 	ctx context.Context,
 	r *resource,
@@ -96,12 +256,22 @@ func (rm *resourceManager) updatePermission(
 	}()
 
 	permissionArn := (*string)(r.ko.Status.ACKResourceMetadata.ARN)
-	version := r.ko.Status.Version
+	previousVersion := r.ko.Status.Version
+	// RAM refuses to create a version once the permission has the maximum
+	// number of versions, so make room for the new one first.
+	if err = rm.deleteOldPermissionVersions(ctx, r, maxPermissionVersions-1); err != nil {
+		return err
+	}
+	template, err := policyTemplate(r)
+	if err != nil {
+		return err
+	}
 	resp, err := rm.sdkapi.CreatePermissionVersion(
 		ctx,
 		&svcsdk.CreatePermissionVersionInput{
 			PermissionArn:  permissionArn,
-			PolicyTemplate: r.ko.Spec.PolicyTemplate,
+			PolicyTemplate: template,
+			ClientToken:    clientToken(r, "CreatePermissionVersion", []string{aws.ToString(template)}),
 		},
 	)
 	rm.metrics.RecordAPICall("UPDATE", "CreatePermissionVersion", err)
@@ -109,11 +279,6 @@ func (rm *resourceManager) updatePermission(
 		return err
 	}
 
-	err = rm.deleteNonDefaultPermissionVersion(ctx, *permissionArn, *version)
-	if err != nil {
-		return err
-	}
-
 	if resp.Permission.CreationTime != nil {
 		r.ko.Status.CreationTime = &metav1.Time{*resp.Permission.CreationTime}
 	} else {
@@ -164,28 +329,389 @@ func (rm *resourceManager) updatePermission(
 	} else {
 		r.ko.Status.Version = nil
 	}
-	if resp.Permission.Permission != nil {
-		r.ko.Spec.PolicyTemplate = resp.Permission.Permission
-	}
+	setPolicyTemplate(r.ko, resp.Permission.Permission)
 
-	dv, err := strconv.ParseInt(*r.ko.Status.Version, 10, 64)
-	if err != nil {
+	if err = rm.setDefaultPermissionVersion(ctx, r, r.ko.Status.Version); err != nil {
 		return err
 	}
-	newdv := int32(dv)
+	if aws.ToBool(r.ko.Spec.ReplacePermissionAssociations) &&
+		r.ko.Spec.PinnedVersion == nil && previousVersion != nil {
+		if err = rm.replacePermissionAssociations(ctx, r, previousVersion); err != nil {
+			return err
+		}
+	}
+
+	return rm.deleteOldPermissionVersions(ctx, r, retainedVersions(r.ko))
+}
+
+// retainedVersions returns the number of versions of the permission to keep.
+func retainedVersions(ko *svcapitypes.Permission) int {
+	if ko.Spec.RetainedVersions == nil {
+		return defaultRetainedVersions
+	}
+	return int(*ko.Spec.RetainedVersions)
+}
+
+// validateRetainedVersions returns a terminal error if Spec.RetainedVersions
+// is outside of the number of versions RAM allows a permission to have.
+func validateRetainedVersions(r *resource) error {
+	if r.ko.Spec.RetainedVersions == nil {
+		return nil
+	}
+	if n := *r.ko.Spec.RetainedVersions; n < 1 || n > maxPermissionVersions {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"invalid retainedVersions %d: must be between 1 and %d",
+			n, maxPermissionVersions,
+		))
+	}
+	return nil
+}
+
+// setDefaultPermissionVersion sets the default version of the permission to
+// Spec.PinnedVersion, or to the supplied most recent version when no version
+// is pinned.
+func (rm *resourceManager) setDefaultPermissionVersion(
+	ctx context.Context,
+	r *resource,
+	mostRecent *string,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.setDefaultPermissionVersion")
+	defer func() {
+		exit(err)
+	}()
+
+	var version int32
+	if r.ko.Spec.PinnedVersion != nil {
+		version = int32(*r.ko.Spec.PinnedVersion)
+	} else {
+		if mostRecent == nil {
+			return nil
+		}
+		v, err := strconv.ParseInt(*mostRecent, 10, 32)
+		if err != nil {
+			return err
+		}
+		version = int32(v)
+	}
 	_, err = rm.sdkapi.SetDefaultPermissionVersion(
 		ctx,
 		&svcsdk.SetDefaultPermissionVersionInput{
-			PermissionArn:     permissionArn,
-			PermissionVersion: &newdv,
+			PermissionArn:     (*string)(r.ko.Status.ACKResourceMetadata.ARN),
+			PermissionVersion: &version,
+		},
+	)
+	rm.metrics.RecordAPICall("UPDATE", "SetDefaultPermissionVersion", err)
+	return err
+}
+
+// deleteOldPermissionVersions deletes the oldest versions of the permission
+// until at most the supplied number of versions is left. The default
+// version and the pinned version are never deleted.
+func (rm *resourceManager) deleteOldPermissionVersions(
+	ctx context.Context,
+	r *resource,
+	retained int,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.deleteOldPermissionVersions")
+	defer func() {
+		exit(err)
+	}()
+
+	permissionArn := *(*string)(r.ko.Status.ACKResourceMetadata.ARN)
+	versions, err := rm.listPermissionVersions(ctx, permissionArn)
+	if err != nil {
+		return err
+	}
+	excess := len(versions) - retained
+	for _, v := range versions {
+		if excess <= 0 {
+			break
+		}
+		if aws.ToBool(v.DefaultVersion) || isPinnedVersion(r, v.Version) ||
+			isReplacedVersion(r, v.Version) {
+			continue
+		}
+		if err = rm.deleteNonDefaultPermissionVersion(ctx, permissionArn, *v.Version); err != nil {
+			return err
+		}
+		excess--
+	}
+	return nil
+}
+
+// isPinnedVersion returns true if the supplied version is the one in
+// Spec.PinnedVersion.
+func isPinnedVersion(r *resource, version *string) bool {
+	return r.ko.Spec.PinnedVersion != nil && version != nil &&
+		*version == strconv.FormatInt(*r.ko.Spec.PinnedVersion, 10)
+}
+
+// isReplacedVersion returns true if resource shares are still being moved
+// away from the supplied version.
+func isReplacedVersion(r *resource, version *string) bool {
+	work := r.ko.Status.ReplacePermissionAssociationsWork
+	return workInProgress(work) && version != nil &&
+		aws.ToString(work.FromPermissionVersion) == *version
+}
+
+// workInProgress returns true if the supplied replacement work hasn't
+// completed or failed yet.
+func workInProgress(work *svcapitypes.ReplacePermissionAssociationsWork) bool {
+	return work != nil && work.Status != nil &&
+		*work.Status == string(svcapitypes.ReplacePermissionAssociationsWorkStatus_IN_PROGRESS)
+}
+
+// replacePermissionAssociations starts moving the resource shares that use
+// the supplied version of the permission to its default version, and
+// records the resulting work in Status.ReplacePermissionAssociationsWork.
+func (rm *resourceManager) replacePermissionAssociations(
+	ctx context.Context,
+	r *resource,
+	fromVersion *string,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.replacePermissionAssociations")
+	defer func() {
+		exit(err)
+	}()
+
+	permissionArn := (*string)(r.ko.Status.ACKResourceMetadata.ARN)
+	version := int32(versionNumber(fromVersion))
+	resp, err := rm.sdkapi.ReplacePermissionAssociations(
+		ctx,
+		&svcsdk.ReplacePermissionAssociationsInput{
+			FromPermissionArn:     permissionArn,
+			FromPermissionVersion: &version,
+			ToPermissionArn:       permissionArn,
 		},
 	)
+	rm.metrics.RecordAPICall("UPDATE", "ReplacePermissionAssociations", err)
+	if err != nil {
+		return err
+	}
+	if resp.ReplacePermissionAssociationsWork != nil {
+		r.ko.Status.ReplacePermissionAssociationsWork = replacePermissionAssociationsWork(
+			resp.ReplacePermissionAssociationsWork,
+		)
+	}
+	return nil
+}
+
+// setReplacePermissionAssociationsWork refreshes the replacement work in
+// Status.ReplacePermissionAssociationsWork while it is in progress. The
+// resource is reported as not synced until the work is COMPLETED or FAILED,
+// so that it keeps being requeued.
+func (rm *resourceManager) setReplacePermissionAssociationsWork(
+	ctx context.Context,
+	ko *svcapitypes.Permission,
+) error {
+	work := ko.Status.ReplacePermissionAssociationsWork
+	if !workInProgress(work) || work.ID == nil {
+		return nil
+	}
+	resp, err := rm.sdkapi.ListReplacePermissionAssociationsWork(
+		ctx,
+		&svcsdk.ListReplacePermissionAssociationsWorkInput{
+			WorkIds: []string{*work.ID},
+		},
+	)
+	rm.metrics.RecordAPICall("READ_MANY", "ListReplacePermissionAssociationsWork", err)
+	if err != nil {
+		return err
+	}
+	if len(resp.ReplacePermissionAssociationsWorks) > 0 {
+		ko.Status.ReplacePermissionAssociationsWork = replacePermissionAssociationsWork(
+			&resp.ReplacePermissionAssociationsWorks[0],
+		)
+	}
+	if workInProgress(ko.Status.ReplacePermissionAssociationsWork) {
+		msg := "waiting for resource shares to be moved to the default version of the permission"
+		ackcondition.SetSynced(&resource{ko}, corev1.ConditionFalse, &msg, nil)
+	}
+	return nil
+}
+
+// replacePermissionAssociationsWork converts the supplied replacement work
+// into its custom resource representation.
+func replacePermissionAssociationsWork(
+	w *svcsdktypes.ReplacePermissionAssociationsWork,
+) *svcapitypes.ReplacePermissionAssociationsWork {
+	res := &svcapitypes.ReplacePermissionAssociationsWork{
+		FromPermissionARN:     w.FromPermissionArn,
+		FromPermissionVersion: w.FromPermissionVersion,
+		ID:                    w.Id,
+		StatusMessage:         w.StatusMessage,
+		ToPermissionARN:       w.ToPermissionArn,
+		ToPermissionVersion:   w.ToPermissionVersion,
+	}
+	if w.CreationTime != nil {
+		res.CreationTime = &metav1.Time{Time: *w.CreationTime}
+	}
+	if w.LastUpdatedTime != nil {
+		res.LastUpdatedTime = &metav1.Time{Time: *w.LastUpdatedTime}
+	}
+	if w.Status != "" {
+		res.Status = aws.String(string(w.Status))
+	}
+	return res
+}
+
+// listPermissionVersions returns all the versions of the permission, ordered
+// from the oldest to the most recent.
+func (rm *resourceManager) listPermissionVersions(
+	ctx context.Context,
+	permissionArn string,
+) (versions []svcsdktypes.ResourceSharePermissionSummary, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.listPermissionVersions")
+	defer func() {
+		exit(err)
+	}()
+
+	input := &svcsdk.ListPermissionVersionsInput{
+		PermissionArn: &permissionArn,
+		MaxResults:    listPageSize(),
+	}
+	for page := 1; ; page++ {
+		resp, err := rm.sdkapi.ListPermissionVersions(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListPermissionVersions", err)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, resp.Permissions...)
+		if resp.NextToken == nil {
+			break
+		}
+		if page >= svcconfig.ListMaxPages {
+			return nil, listLimitExceeded("ListPermissionVersions")
+		}
+		input.NextToken = resp.NextToken
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versionNumber(versions[i].Version) < versionNumber(versions[j].Version)
+	})
+	return versions, nil
+}
+
+// versionNumber returns the supplied permission version as a number, or 0
+// if it isn't one.
+func versionNumber(version *string) int64 {
+	if version == nil {
+		return 0
+	}
+	v, _ := strconv.ParseInt(*version, 10, 64)
+	return v
+}
+
+// latestVersion returns the most recent of the supplied versions.
+func latestVersion(versions []*svcapitypes.ResourceSharePermissionSummary) *string {
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[len(versions)-1].Version
+}
+
+// setPermissionVersions lists the versions of the permission into
+// Status.Versions. The policy template always describes the most recent
+// version, so it is read again when an earlier version is the default one.
+// In that case the default version is also reported in Spec.PinnedVersion,
+// so that a default version that doesn't match the desired one shows up in
+// the delta.
+func (rm *resourceManager) setPermissionVersions(
+	ctx context.Context,
+	desired *resource,
+	ko *svcapitypes.Permission,
+) error {
+	versions, err := rm.listPermissionVersions(ctx, string(*ko.Status.ACKResourceMetadata.ARN))
 	if err != nil {
 		return err
 	}
+	ko.Status.Versions = make([]*svcapitypes.ResourceSharePermissionSummary, 0, len(versions))
+	for i := range versions {
+		ko.Status.Versions = append(ko.Status.Versions, permissionSummary(&versions[i]))
+	}
 
+	mostRecent := latestVersion(ko.Status.Versions)
+	var defaultVersion *string
+	for _, v := range ko.Status.Versions {
+		if aws.ToBool(v.DefaultVersion) {
+			defaultVersion = v.Version
+		}
+	}
+	if mostRecent == nil || defaultVersion == nil {
+		return nil
+	}
+	if *defaultVersion != *mostRecent || desired.ko.Spec.PinnedVersion != nil {
+		pinned := versionNumber(defaultVersion)
+		ko.Spec.PinnedVersion = &pinned
+	} else {
+		ko.Spec.PinnedVersion = nil
+	}
+	if *defaultVersion == *mostRecent {
+		return nil
+	}
+
+	v := int32(versionNumber(mostRecent))
+	resp, err := rm.sdkapi.GetPermission(
+		ctx,
+		&svcsdk.GetPermissionInput{
+			PermissionArn:     (*string)(ko.Status.ACKResourceMetadata.ARN),
+			PermissionVersion: &v,
+		},
+	)
+	rm.metrics.RecordAPICall("READ_ONE", "GetPermission", err)
+	if err != nil {
+		return err
+	}
+	if resp.Permission != nil {
+		setPolicyTemplate(ko, resp.Permission.Permission)
+	}
 	return nil
+}
+
+// setPolicyTemplate records the policy template RAM returned for the most
+// recent version of the permission in Status.ObservedPolicyTemplate. It is
+// only copied to Spec.PolicyTemplate when the permission isn't described by
+// Spec.PolicyStatements, since exactly one of the two may be specified.
+func setPolicyTemplate(ko *svcapitypes.Permission, template *string) {
+	if template == nil {
+		return
+	}
+	ko.Status.ObservedPolicyTemplate = template
+	if ko.Spec.PolicyStatements == nil {
+		ko.Spec.PolicyTemplate = template
+	}
+}
 
+// permissionSummary converts the supplied permission version into its
+// custom resource representation.
+func permissionSummary(
+	v *svcsdktypes.ResourceSharePermissionSummary,
+) *svcapitypes.ResourceSharePermissionSummary {
+	res := &svcapitypes.ResourceSharePermissionSummary{
+		ARN:                   v.Arn,
+		DefaultVersion:        v.DefaultVersion,
+		IsResourceTypeDefault: v.IsResourceTypeDefault,
+		Name:                  v.Name,
+		ResourceType:          v.ResourceType,
+		Status:                v.Status,
+		Version:               v.Version,
+	}
+	if v.CreationTime != nil {
+		res.CreationTime = &metav1.Time{Time: *v.CreationTime}
+	}
+	if v.LastUpdatedTime != nil {
+		res.LastUpdatedTime = &metav1.Time{Time: *v.LastUpdatedTime}
+	}
+	if v.FeatureSet != "" {
+		res.FeatureSet = aws.String(string(v.FeatureSet))
+	}
+	if v.PermissionType != "" {
+		res.PermissionType = aws.String(string(v.PermissionType))
+	}
+	return res
 }
 
 func (rm *resourceManager) deleteNonDefaultPermissionVersion(
@@ -0,0 +1,133 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package permission
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+// newVersions returns the summaries of the versions 1 to n of a permission
+// whose default version is the supplied one.
+func newVersions(n int, defaultVersion int) []*svcapitypes.ResourceSharePermissionSummary {
+	versions := make([]*svcapitypes.ResourceSharePermissionSummary, 0, n)
+	for v := 1; v <= n; v++ {
+		versions = append(versions, &svcapitypes.ResourceSharePermissionSummary{
+			Version:        aws.String(string(rune('0' + v))),
+			DefaultVersion: aws.Bool(v == defaultVersion),
+		})
+	}
+	return versions
+}
+
+func TestHasExcessVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     svcapitypes.PermissionSpec
+		status   svcapitypes.PermissionStatus
+		versions []*svcapitypes.ResourceSharePermissionSummary
+		want     bool
+	}{
+		{
+			name:     "single version",
+			versions: newVersions(1, 1),
+		},
+		{
+			name:     "more versions than the default retained version",
+			versions: newVersions(2, 2),
+			want:     true,
+		},
+		{
+			name:     "as many versions as retained",
+			spec:     svcapitypes.PermissionSpec{RetainedVersions: aws.Int64(3)},
+			versions: newVersions(3, 3),
+		},
+		{
+			name:     "retained versions lowered",
+			spec:     svcapitypes.PermissionSpec{RetainedVersions: aws.Int64(2)},
+			versions: newVersions(3, 3),
+			want:     true,
+		},
+		{
+			name:     "only the default and pinned versions are left",
+			spec:     svcapitypes.PermissionSpec{PinnedVersion: aws.Int64(1)},
+			versions: newVersions(2, 2),
+		},
+		{
+			name:     "pinned version and a version that can be deleted",
+			spec:     svcapitypes.PermissionSpec{PinnedVersion: aws.Int64(2)},
+			versions: newVersions(3, 3),
+			want:     true,
+		},
+		{
+			name: "only the default version and a version being replaced are left",
+			status: svcapitypes.PermissionStatus{
+				ReplacePermissionAssociationsWork: &svcapitypes.ReplacePermissionAssociationsWork{
+					FromPermissionVersion: aws.String("1"),
+					Status:                aws.String(string(svcapitypes.ReplacePermissionAssociationsWorkStatus_IN_PROGRESS)),
+				},
+			},
+			versions: newVersions(2, 2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resource{&svcapitypes.Permission{Spec: tt.spec, Status: tt.status}}
+			if got := hasExcessVersions(r, tt.versions); got != tt.want {
+				t.Errorf("hasExcessVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomPreCompareRetainedVersions(t *testing.T) {
+	template := aws.String(`{"Action":"ec2:DescribeSubnets","Effect":"Allow"}`)
+	tests := []struct {
+		name     string
+		retained *int64
+		versions []*svcapitypes.ResourceSharePermissionSummary
+		want     bool
+	}{
+		{
+			name:     "retained versions only",
+			retained: aws.Int64(2),
+			versions: newVersions(2, 2),
+		},
+		{
+			name:     "versions beyond the retained ones",
+			retained: aws.Int64(1),
+			versions: newVersions(2, 2),
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{&svcapitypes.Permission{
+				Spec: svcapitypes.PermissionSpec{PolicyTemplate: template, RetainedVersions: tt.retained},
+			}}
+			latest := &resource{desired.ko.DeepCopy()}
+			latest.ko.Status.Versions = tt.versions
+			delta := newResourceDelta(desired, latest)
+			if got := delta.DifferentAt("Spec.RetainedVersions"); got != tt.want {
+				t.Errorf("DifferentAt(Spec.RetainedVersions) = %v, want %v", got, tt.want)
+			}
+			if delta.DifferentAt("Spec.PolicyTemplate") {
+				t.Errorf("DifferentAt(Spec.PolicyTemplate) = true, want false")
+			}
+		})
+	}
+}
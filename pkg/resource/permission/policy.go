@@ -0,0 +1,388 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package permission
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+const (
+	// policyEffectAllow is the only effect RAM supports in policy templates.
+	policyEffectAllow = "Allow"
+)
+
+var (
+	// policyActionRegexp matches an action of a policy statement: a service
+	// prefix followed by an action name that may contain wildcards.
+	policyActionRegexp = regexp.MustCompile(`^[a-zA-Z0-9-]+:[a-zA-Z0-9*?]+$`)
+	// policyConditionOperatorRegexp matches a condition operator, optionally
+	// qualified with a set operator such as ForAnyValue.
+	policyConditionOperatorRegexp = regexp.MustCompile(`^([a-zA-Z]+:)?[a-zA-Z]+$`)
+)
+
+// policyTemplate returns the policy template to send to RAM for the supplied
+// resource: Spec.PolicyTemplate, or Spec.PolicyStatements rendered to JSON.
+func policyTemplate(r *resource) (*string, error) {
+	if r.ko.Spec.PolicyStatements == nil {
+		return r.ko.Spec.PolicyTemplate, nil
+	}
+	template, err := renderPolicyStatements(r.ko.Spec.PolicyStatements)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// validatePolicy returns a terminal error unless exactly one of
+// Spec.PolicyTemplate and Spec.PolicyStatements is specified and valid, so
+// that mistakes are reported before RAM is called.
+func validatePolicy(r *resource) error {
+	spec := r.ko.Spec
+	if (spec.PolicyTemplate == nil) == (spec.PolicyStatements == nil) {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"exactly one of policyTemplate and policyStatements must be specified",
+		))
+	}
+	var actions []string
+	if spec.PolicyTemplate != nil {
+		var err error
+		if actions, err = policyTemplateActions(*spec.PolicyTemplate); err != nil {
+			return ackerr.NewTerminalError(fmt.Errorf(
+				"invalid policyTemplate: %v", err,
+			))
+		}
+	} else {
+		if len(spec.PolicyStatements) == 0 {
+			return ackerr.NewTerminalError(fmt.Errorf(
+				"invalid policyStatements: at least one statement must be specified",
+			))
+		}
+		for i, statement := range spec.PolicyStatements {
+			if err := validatePolicyStatement(statement); err != nil {
+				return ackerr.NewTerminalError(fmt.Errorf(
+					"invalid policyStatements[%d]: %v", i, err,
+				))
+			}
+			actions = append(actions, aws.ToStringSlice(statement.Actions)...)
+		}
+	}
+	return validatePolicyActions(aws.ToString(spec.ResourceType), actions)
+}
+
+// validatePolicyActions returns a terminal error if the supplied resource
+// type isn't in the catalog of the AWS managed permissions, or listing the
+// supplied actions that a permission for the resource type can't grant.
+func validatePolicyActions(resourceType string, actions []string) error {
+	supported, ok := supportedActions(resourceType)
+	if !ok {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"resource type %q isn't a resource type that can be shared with RAM",
+			resourceType,
+		))
+	}
+	unsupported := []string{}
+	for _, action := range actions {
+		if !actionSupported(action, supported) {
+			unsupported = append(unsupported, action)
+		}
+	}
+	if len(unsupported) == 0 {
+		return nil
+	}
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"actions %s can't be granted by a permission for resource type %s",
+		strings.Join(unsupported, ", "), resourceType,
+	))
+}
+
+// policyTemplateActions returns the actions of the statements of the
+// supplied policy template.
+func policyTemplateActions(template string) ([]string, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(template), &doc); err != nil {
+		return nil, err
+	}
+	if m, ok := doc.(map[string]interface{}); ok {
+		if statements, ok := m["Statement"]; ok {
+			doc = statements
+		}
+	}
+	actions := []string{}
+	for _, statement := range asList(doc) {
+		m, ok := statement.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("statements must be JSON objects")
+		}
+		for _, action := range asList(m["Action"]) {
+			if a, ok := action.(string); ok {
+				actions = append(actions, a)
+			}
+		}
+	}
+	return actions, nil
+}
+
+// validatePolicyStatement returns an error describing the first problem
+// found in the supplied statement.
+func validatePolicyStatement(s *svcapitypes.PermissionPolicyStatement) error {
+	if s == nil {
+		return fmt.Errorf("statement is empty")
+	}
+	if s.Effect != nil && *s.Effect != policyEffectAllow {
+		return fmt.Errorf("effect %q is not supported, only %s is", *s.Effect, policyEffectAllow)
+	}
+	if len(s.Actions) == 0 {
+		return fmt.Errorf("at least one action must be specified")
+	}
+	for _, action := range s.Actions {
+		if !policyActionRegexp.MatchString(aws.ToString(action)) {
+			return fmt.Errorf("action %q must be of the form service:Action", aws.ToString(action))
+		}
+	}
+	for j, c := range s.Conditions {
+		if c == nil {
+			return fmt.Errorf("conditions[%d] is empty", j)
+		}
+		if !policyConditionOperatorRegexp.MatchString(aws.ToString(c.Operator)) {
+			return fmt.Errorf("conditions[%d]: invalid operator %q", j, aws.ToString(c.Operator))
+		}
+		if strings.TrimSpace(aws.ToString(c.Key)) == "" {
+			return fmt.Errorf("conditions[%d]: key must be specified", j)
+		}
+		if len(c.Values) == 0 {
+			return fmt.Errorf("conditions[%d]: at least one value must be specified", j)
+		}
+	}
+	return nil
+}
+
+// renderPolicyStatements renders the supplied statements to a policy
+// template. A single statement is rendered as a JSON object, several
+// statements as a JSON array.
+func renderPolicyStatements(
+	statements []*svcapitypes.PermissionPolicyStatement,
+) (string, error) {
+	rendered := make([]map[string]interface{}, 0, len(statements))
+	for _, s := range statements {
+		effect := policyEffectAllow
+		if s.Effect != nil {
+			effect = *s.Effect
+		}
+		statement := map[string]interface{}{
+			"Effect": effect,
+			"Action": aws.ToStringSlice(s.Actions),
+		}
+		if len(s.Conditions) > 0 {
+			conditions := map[string]map[string][]string{}
+			for _, c := range s.Conditions {
+				operator := aws.ToString(c.Operator)
+				if conditions[operator] == nil {
+					conditions[operator] = map[string][]string{}
+				}
+				key := aws.ToString(c.Key)
+				conditions[operator][key] = append(
+					conditions[operator][key], aws.ToStringSlice(c.Values)...,
+				)
+			}
+			statement["Condition"] = conditions
+		}
+		rendered = append(rendered, statement)
+	}
+	var doc interface{} = rendered
+	if len(rendered) == 1 {
+		doc = rendered[0]
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// policyTemplatesEqual returns true if the supplied policy templates describe
+// the same policy. RAM doesn't echo policy templates byte for byte: it may
+// change the whitespace and the order of the keys, turn a single statement
+// into a list of statements, or a single action into a list of actions. Both
+// documents are therefore normalized before being compared. Templates that
+// aren't valid JSON are compared as strings.
+func policyTemplatesEqual(a, b string) bool {
+	na, err := normalizePolicyTemplate(a)
+	if err != nil {
+		return a == b
+	}
+	nb, err := normalizePolicyTemplate(b)
+	if err != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+// normalizePolicyTemplate parses the supplied policy template into a value
+// that is equal, in the reflect.DeepEqual sense, to the value of any other
+// template describing the same policy.
+func normalizePolicyTemplate(template string) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader([]byte(template)))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the policy template")
+	}
+	// A template is either a single statement or a list of statements.
+	if _, ok := doc.([]interface{}); ok {
+		return normalizeStatements(doc), nil
+	}
+	if m, ok := doc.(map[string]interface{}); ok {
+		if _, ok := m["Statement"]; !ok {
+			return normalizeStatements(doc), nil
+		}
+	}
+	return normalizePolicyElement(doc), nil
+}
+
+// normalizePolicyElement normalizes a policy document or a statement. The
+// elements whose value is a set of strings, or a set of statements, accept
+// either a single value or a list of values, in any order.
+func normalizePolicyElement(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	res := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		switch key {
+		case "Statement":
+			res[key] = normalizeStatements(value)
+		case "Action", "NotAction", "Resource", "NotResource":
+			res[key] = stringSet(value)
+		case "Principal", "NotPrincipal":
+			res[key] = normalizePrincipal(value)
+		case "Condition":
+			res[key] = normalizeCondition(value)
+		default:
+			res[key] = value
+		}
+	}
+	return res
+}
+
+// normalizeStatements returns the supplied statement, or list of statements,
+// as a sorted list of normalized statements without duplicates.
+func normalizeStatements(v interface{}) interface{} {
+	statements := asList(v)
+	byKey := make(map[string]interface{}, len(statements))
+	for _, s := range statements {
+		normalized := normalizePolicyElement(s)
+		key, err := json.Marshal(normalized)
+		if err != nil {
+			return v
+		}
+		byKey[string(key)] = normalized
+	}
+	keys := make([]string, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, byKey[k])
+	}
+	return res
+}
+
+// normalizePrincipal normalizes the value of a Principal element, which is
+// either "*" or a map from principal types to sets of principals.
+func normalizePrincipal(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	res := make(map[string]interface{}, len(m))
+	for principalType, principals := range m {
+		res[principalType] = stringSet(principals)
+	}
+	return res
+}
+
+// normalizeCondition normalizes the value of a Condition element, which maps
+// condition operators to maps from condition keys to sets of values.
+func normalizeCondition(v interface{}) interface{} {
+	operators, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	res := make(map[string]interface{}, len(operators))
+	for operator, keys := range operators {
+		m, ok := keys.(map[string]interface{})
+		if !ok {
+			res[operator] = keys
+			continue
+		}
+		values := make(map[string]interface{}, len(m))
+		for key, value := range m {
+			values[key] = stringSet(value)
+		}
+		res[operator] = values
+	}
+	return res
+}
+
+// stringSet returns the supplied value, or list of values, as a sorted list
+// of strings without duplicates. Numbers and booleans are compared through
+// their JSON representation, the way IAM evaluates them. Values that aren't
+// scalars are returned as is.
+func stringSet(v interface{}) interface{} {
+	set := map[string]struct{}{}
+	for _, elem := range asList(v) {
+		switch e := elem.(type) {
+		case string:
+			set[e] = struct{}{}
+		case json.Number:
+			set[e.String()] = struct{}{}
+		case bool:
+			set[fmt.Sprint(e)] = struct{}{}
+		default:
+			return v
+		}
+	}
+	res := make([]string, 0, len(set))
+	for s := range set {
+		res = append(res, s)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// asList returns the supplied value if it is a list, or a list holding only
+// the supplied value otherwise.
+func asList(v interface{}) []interface{} {
+	if l, ok := v.([]interface{}); ok {
+		return l
+	}
+	return []interface{}{v}
+}
@@ -0,0 +1,408 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package permission
+
+import (
+	"errors"
+	"testing"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+func TestPolicyTemplatesEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			name: "identical",
+			a:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets"]}`,
+			b:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets"]}`,
+			want: true,
+		},
+		{
+			name: "whitespace and key order",
+			a:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets"]}`,
+			b:    "{\n  \"Action\": [\"ec2:DescribeSubnets\"],\n  \"Effect\": \"Allow\"\n}",
+			want: true,
+		},
+		{
+			name: "single action and list of actions",
+			a:    `{"Effect":"Allow","Action":"ec2:DescribeSubnets"}`,
+			b:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets"]}`,
+			want: true,
+		},
+		{
+			name: "order of actions",
+			a:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets","ec2:DescribeVpcs"]}`,
+			b:    `{"Effect":"Allow","Action":["ec2:DescribeVpcs","ec2:DescribeSubnets"]}`,
+			want: true,
+		},
+		{
+			name: "single statement and list of statements",
+			a:    `{"Effect":"Allow","Action":"ec2:DescribeSubnets"}`,
+			b:    `[{"Effect":"Allow","Action":"ec2:DescribeSubnets"}]`,
+			want: true,
+		},
+		{
+			name: "order of statements",
+			a:    `[{"Effect":"Allow","Action":"ec2:DescribeSubnets"},{"Effect":"Allow","Action":"ec2:DescribeVpcs"}]`,
+			b:    `[{"Effect":"Allow","Action":"ec2:DescribeVpcs"},{"Effect":"Allow","Action":"ec2:DescribeSubnets"}]`,
+			want: true,
+		},
+		{
+			name: "policy document and list of statements",
+			a:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["ec2:DescribeSubnets","ec2:DescribeVpcs"]}]}`,
+			b:    `{"Version":"2012-10-17","Statement":{"Action":["ec2:DescribeVpcs","ec2:DescribeSubnets"],"Effect":"Allow"}}`,
+			want: true,
+		},
+		{
+			name: "condition values in any order",
+			a:    `{"Effect":"Allow","Action":"ec2:DescribeSubnets","Condition":{"StringEquals":{"aws:PrincipalTag/team":["a","b"]}}}`,
+			b:    `{"Effect":"Allow","Action":"ec2:DescribeSubnets","Condition":{"StringEquals":{"aws:PrincipalTag/team":["b","a"]}}}`,
+			want: true,
+		},
+		{
+			name: "different actions",
+			a:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets"]}`,
+			b:    `{"Effect":"Allow","Action":["ec2:DescribeVpcs"]}`,
+			want: false,
+		},
+		{
+			name: "additional action",
+			a:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets"]}`,
+			b:    `{"Effect":"Allow","Action":["ec2:DescribeSubnets","ec2:DescribeVpcs"]}`,
+			want: false,
+		},
+		{
+			name: "different condition",
+			a:    `{"Effect":"Allow","Action":"ec2:DescribeSubnets","Condition":{"StringEquals":{"aws:PrincipalTag/team":"a"}}}`,
+			b:    `{"Effect":"Allow","Action":"ec2:DescribeSubnets","Condition":{"StringEquals":{"aws:PrincipalTag/team":"b"}}}`,
+			want: false,
+		},
+		{
+			name: "invalid JSON compared as strings",
+			a:    `{"Effect":`,
+			b:    `{"Effect":`,
+			want: true,
+		},
+		{
+			name: "invalid and valid JSON",
+			a:    `{"Effect":`,
+			b:    `{"Effect":"Allow"}`,
+			want: false,
+		},
+		{
+			name: "trailing data",
+			a:    `{"Effect":"Allow"} {}`,
+			b:    `{"Effect":"Allow"}`,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policyTemplatesEqual(tt.a, tt.b); got != tt.want {
+				t.Errorf("policyTemplatesEqual(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := policyTemplatesEqual(tt.b, tt.a); got != tt.want {
+				t.Errorf("policyTemplatesEqual(%s, %s) = %v, want %v", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPolicyStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []*svcapitypes.PermissionPolicyStatement
+		want       string
+	}{
+		{
+			name: "single statement is rendered as an object",
+			statements: []*svcapitypes.PermissionPolicyStatement{{
+				Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"}),
+			}},
+			want: `{"Action":["ec2:DescribeSubnets"],"Effect":"Allow"}`,
+		},
+		{
+			name: "several statements are rendered as a list",
+			statements: []*svcapitypes.PermissionPolicyStatement{
+				{Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"})},
+				{Actions: aws.StringSlice([]string{"ec2:DescribeVpcs"}), Effect: aws.String("Allow")},
+			},
+			want: `[{"Action":["ec2:DescribeSubnets"],"Effect":"Allow"},{"Action":["ec2:DescribeVpcs"],"Effect":"Allow"}]`,
+		},
+		{
+			name: "conditions are grouped by operator and key",
+			statements: []*svcapitypes.PermissionPolicyStatement{{
+				Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"}),
+				Conditions: []*svcapitypes.PermissionPolicyCondition{
+					{Operator: aws.String("StringEquals"), Key: aws.String("aws:PrincipalTag/team"), Values: aws.StringSlice([]string{"a"})},
+					{Operator: aws.String("StringEquals"), Key: aws.String("aws:PrincipalTag/team"), Values: aws.StringSlice([]string{"b"})},
+					{Operator: aws.String("Bool"), Key: aws.String("aws:SecureTransport"), Values: aws.StringSlice([]string{"true"})},
+				},
+			}},
+			want: `{"Action":["ec2:DescribeSubnets"],"Condition":{"Bool":{"aws:SecureTransport":["true"]},"StringEquals":{"aws:PrincipalTag/team":["a","b"]}},"Effect":"Allow"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderPolicyStatements(tt.statements)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("renderPolicyStatements() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	statement := &svcapitypes.PermissionPolicyStatement{
+		Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"}),
+	}
+	tests := []struct {
+		name    string
+		spec    svcapitypes.PermissionSpec
+		wantErr bool
+	}{
+		{
+			name: "policy template",
+			spec: svcapitypes.PermissionSpec{
+				PolicyTemplate: aws.String(`{"Effect":"Allow","Action":"ec2:DescribeSubnets"}`),
+			},
+		},
+		{
+			name: "policy statements",
+			spec: svcapitypes.PermissionSpec{
+				PolicyStatements: []*svcapitypes.PermissionPolicyStatement{statement},
+			},
+		},
+		{
+			name:    "neither",
+			spec:    svcapitypes.PermissionSpec{},
+			wantErr: true,
+		},
+		{
+			name: "both",
+			spec: svcapitypes.PermissionSpec{
+				PolicyTemplate:   aws.String(`{"Effect":"Allow","Action":"ec2:DescribeSubnets"}`),
+				PolicyStatements: []*svcapitypes.PermissionPolicyStatement{statement},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty policy statements",
+			spec: svcapitypes.PermissionSpec{
+				PolicyStatements: []*svcapitypes.PermissionPolicyStatement{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "deny effect",
+			spec: svcapitypes.PermissionSpec{
+				PolicyStatements: []*svcapitypes.PermissionPolicyStatement{{
+					Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"}),
+					Effect:  aws.String("Deny"),
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed action",
+			spec: svcapitypes.PermissionSpec{
+				PolicyStatements: []*svcapitypes.PermissionPolicyStatement{{
+					Actions: aws.StringSlice([]string{"DescribeSubnets"}),
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "condition without values",
+			spec: svcapitypes.PermissionSpec{
+				PolicyStatements: []*svcapitypes.PermissionPolicyStatement{{
+					Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"}),
+					Conditions: []*svcapitypes.PermissionPolicyCondition{{
+						Operator: aws.String("StringEquals"),
+						Key:      aws.String("aws:PrincipalTag/team"),
+					}},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid policy template",
+			spec: svcapitypes.PermissionSpec{
+				PolicyTemplate: aws.String(`{"Effect":`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.spec.ResourceType == nil {
+				tt.spec.ResourceType = aws.String("ec2:Subnet")
+			}
+			err := validatePolicy(&resource{&svcapitypes.Permission{Spec: tt.spec}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCustomPreComparePolicyStatements(t *testing.T) {
+	statements := []*svcapitypes.PermissionPolicyStatement{{
+		Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"}),
+	}}
+	tests := []struct {
+		name     string
+		observed *string
+		want     bool
+	}{
+		{
+			name:     "template read back from RAM matches the statements",
+			observed: aws.String(`{"Action":"ec2:DescribeSubnets","Effect":"Allow"}`),
+			want:     false,
+		},
+		{
+			name:     "template read back from RAM differs from the statements",
+			observed: aws.String(`{"Action":"ec2:DescribeVpcs","Effect":"Allow"}`),
+			want:     true,
+		},
+		{
+			name: "template not read back yet",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{&svcapitypes.Permission{
+				Spec: svcapitypes.PermissionSpec{PolicyStatements: statements},
+			}}
+			latest := &resource{desired.ko.DeepCopy()}
+			latest.ko.Status.ObservedPolicyTemplate = tt.observed
+			delta := ackcompare.NewDelta()
+			customPreCompare(delta, desired, latest)
+			if got := delta.DifferentAt("Spec.PolicyTemplate"); got != tt.want {
+				t.Errorf("DifferentAt(Spec.PolicyTemplate) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPolicyTemplate(t *testing.T) {
+	template := aws.String(`{"Action":"ec2:DescribeSubnets","Effect":"Allow"}`)
+
+	ko := &svcapitypes.Permission{}
+	setPolicyTemplate(ko, template)
+	if ko.Spec.PolicyTemplate != template || ko.Status.ObservedPolicyTemplate != template {
+		t.Errorf("policy template not recorded in spec and status of a template permission")
+	}
+
+	ko = &svcapitypes.Permission{Spec: svcapitypes.PermissionSpec{
+		PolicyStatements: []*svcapitypes.PermissionPolicyStatement{{
+			Actions: aws.StringSlice([]string{"ec2:DescribeSubnets"}),
+		}},
+	}}
+	setPolicyTemplate(ko, template)
+	if ko.Spec.PolicyTemplate != nil {
+		t.Errorf("policy template set in the spec of a permission described by statements")
+	}
+	if ko.Status.ObservedPolicyTemplate != template {
+		t.Errorf("policy template not recorded in the status of a permission described by statements")
+	}
+}
+
+func TestValidatePolicyActions(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		actions      []string
+		wantErr      bool
+	}{
+		{
+			name:         "supported actions",
+			resourceType: "ec2:Subnet",
+			actions:      []string{"ec2:DescribeSubnets", "ec2:CreateTags"},
+		},
+		{
+			name:         "resource type and actions differ in case",
+			resourceType: "EC2:Subnet",
+			actions:      []string{"Ec2:DescribeSubnets"},
+		},
+		{
+			name:         "wildcard matching supported actions",
+			resourceType: "imagebuilder:Component",
+			actions:      []string{"imagebuilder:*", "imagebuilder:GetComp?nent"},
+		},
+		{
+			name:         "unsupported action of the resource type service",
+			resourceType: "ec2:Subnet",
+			actions:      []string{"ec2:DescribeSubnets", "ec2:DeleteSubnet"},
+			wantErr:      true,
+		},
+		{
+			name:         "wildcard matching no supported action",
+			resourceType: "imagebuilder:Component",
+			actions:      []string{"imagebuilder:Delete*"},
+			wantErr:      true,
+		},
+		{
+			name:         "action of another service",
+			resourceType: "ec2:Subnet",
+			actions:      []string{"ec2:DescribeSubnets", "s3:GetObject"},
+			wantErr:      true,
+		},
+		{
+			name:         "action without a service",
+			resourceType: "ec2:Subnet",
+			actions:      []string{"DescribeSubnets"},
+			wantErr:      true,
+		},
+		{
+			name:         "misspelled resource type",
+			resourceType: "ec2:Subnett",
+			actions:      []string{"ec2:DescribeSubnets"},
+			wantErr:      true,
+		},
+		{
+			name:         "resource type without a service",
+			resourceType: "foo",
+			actions:      []string{"ec2:DescribeSubnets"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePolicyActions(tt.resourceType, tt.actions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatePolicyActions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var terminal *ackerr.TerminalError
+				if !errors.As(err, &terminal) {
+					t.Errorf("validatePolicyActions() error = %v, want a terminal error", err)
+				}
+			}
+		})
+	}
+}
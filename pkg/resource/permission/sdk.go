@@ -165,9 +165,14 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
-	if resp.Permission.Permission != nil {
-		ko.Spec.PolicyTemplate = resp.Permission.Permission
+	setPolicyTemplate(ko, resp.Permission.Permission)
+	if err = rm.setPermissionVersions(ctx, r, ko); err != nil {
+		return nil, err
+	}
+	if err = rm.setReplacePermissionAssociationsWork(ctx, ko); err != nil {
+		return nil, err
 	}
+	setPermissionReadiness(r, ko)
 
 	return &resource{ko}, nil
 }
@@ -208,10 +213,17 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if err = validatePolicy(desired); err != nil {
+		return nil, err
+	}
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
+	if input.PolicyTemplate, err = policyTemplate(desired); err != nil {
+		return nil, err
+	}
+	input.ClientToken = clientToken(desired, "CreatePermission")
 
 	var resp *svcsdk.CreatePermissionOutput
 	_ = resp
@@ -298,6 +310,8 @@ func (rm *resourceManager) sdkCreate(
 		ko.Status.Version = nil
 	}
 
+	bumpClientTokenNonce(ko)
+
 	rm.setStatusDefaults(ko)
 	return &resource{ko}, nil
 }
@@ -0,0 +1,182 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"context"
+	"fmt"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+const (
+	// associationModeAuthoritative makes the associations of the resource
+	// share match its Spec exactly. It is the default mode.
+	associationModeAuthoritative = "Authoritative"
+	// associationModeAdditive only disassociates the principals, resources
+	// and sources the controller associated itself.
+	associationModeAdditive = "Additive"
+)
+
+// validateAssociationMode returns a terminal error if Spec.AssociationMode
+// isn't one of the supported modes.
+func validateAssociationMode(r *resource) error {
+	switch aws.ToString(r.ko.Spec.AssociationMode) {
+	case "", associationModeAuthoritative, associationModeAdditive:
+		return nil
+	}
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"invalid associationMode %q: must be one of %s and %s",
+		*r.ko.Spec.AssociationMode,
+		associationModeAuthoritative, associationModeAdditive,
+	))
+}
+
+// isAdditive returns whether the resource share is in the Additive
+// association mode.
+func isAdditive(ko *svcapitypes.ResourceShare) bool {
+	return aws.ToString(ko.Spec.AssociationMode) == associationModeAdditive
+}
+
+// associationChanges returns the entities to associate with the resource
+// share and the ones to disassociate from it.
+//
+// A nil desired list means the resource share doesn't manage that kind of
+// association at all: nothing is associated or disassociated, which leaves
+// the associations to ResourceShareAssociation resources. An empty desired
+// list, on the other hand, disassociates every entity the resource share may
+// disassociate. The entities associated by ResourceShareAssociation
+// resources, supplied as fromAssociations, are never disassociated, and in
+// the Additive mode only the managed entities are.
+func associationChanges(
+	ko *svcapitypes.ResourceShare,
+	desired []*string,
+	latest []*string,
+	managed []*string,
+	fromAssociations []*string,
+) (toAdd []string, toDelete []string) {
+	if desired == nil {
+		return nil, nil
+	}
+	toAdd, toDelete = compareStringSlices(desired, latest)
+	managedSet := map[string]bool{}
+	for _, v := range managed {
+		managedSet[*v] = true
+	}
+	associationSet := map[string]bool{}
+	for _, v := range fromAssociations {
+		associationSet[*v] = true
+	}
+	owned := make([]string, 0, len(toDelete))
+	for _, v := range toDelete {
+		if associationSet[v] || (isAdditive(ko) && !managedSet[v]) {
+			continue
+		}
+		owned = append(owned, v)
+	}
+	return toAdd, owned
+}
+
+// setEntitiesFromAssociations records in the status of the resource share
+// the principals and the resources that ResourceShareAssociation resources
+// associate with it, so that the resource share doesn't disassociate them.
+func setEntitiesFromAssociations(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) error {
+	associations := &svcapitypes.ResourceShareAssociationList{}
+	if err := apiReader.List(ctx, associations); err != nil {
+		return err
+	}
+	var principals, resourceARNs []*string
+	for i := range associations.Items {
+		association := &associations.Items[i]
+		if !association.DeletionTimestamp.IsZero() || !associatesWith(association, ko) {
+			continue
+		}
+		if association.Spec.Principal != nil {
+			principals = append(principals, aws.String(*association.Spec.Principal))
+		}
+		if association.Spec.ResourceARN != nil {
+			resourceARNs = append(resourceARNs, aws.String(*association.Spec.ResourceARN))
+		}
+	}
+	ko.Status.PrincipalsFromAssociations = principals
+	ko.Status.ResourceARNsFromAssociations = resourceARNs
+	return nil
+}
+
+// associatesWith returns whether the supplied ResourceShareAssociation
+// associates an entity with the supplied resource share, either through a
+// reference to it or through its ARN.
+func associatesWith(
+	association *svcapitypes.ResourceShareAssociation,
+	ko *svcapitypes.ResourceShare,
+) bool {
+	if ref := association.Spec.ResourceShareRef; ref != nil && ref.From != nil {
+		namespace := association.Namespace
+		if ref.From.Namespace != nil && *ref.From.Namespace != "" {
+			namespace = *ref.From.Namespace
+		}
+		return aws.ToString(ref.From.Name) == ko.Name && namespace == ko.Namespace
+	}
+	arn := resourceShareARN(ko)
+	return arn != "" && aws.ToString(association.Spec.ResourceShareARN) == arn
+}
+
+// managedEntities returns the entities the controller manages once the
+// supplied ones were associated with the resource share: the ones it already
+// managed or just associated that are still desired. It returns nil outside
+// of the Additive mode, and the managed entities untouched when the resource
+// share doesn't manage that kind of association.
+func managedEntities(
+	ko *svcapitypes.ResourceShare,
+	desired []*string,
+	managed []*string,
+	added []string,
+) []*string {
+	if !isAdditive(ko) {
+		return nil
+	}
+	if desired == nil {
+		return managed
+	}
+	set := map[string]bool{}
+	for _, v := range managed {
+		set[*v] = true
+	}
+	for _, v := range added {
+		set[v] = true
+	}
+	entities := []*string{}
+	for _, v := range desired {
+		if set[*v] {
+			entities = append(entities, aws.String(*v))
+		}
+	}
+	return entities
+}
+
+// setManagedAssociations records the principals, resources and sources the
+// resource share was created with as managed by the controller.
+func setManagedAssociations(ko *svcapitypes.ResourceShare) {
+	ko.Status.ManagedPrincipals = managedEntities(ko, ko.Spec.Principals, nil, aws.ToStringSlice(ko.Spec.Principals))
+	ko.Status.ManagedResourceARNs = managedEntities(ko, ko.Spec.ResourceARNs, nil, aws.ToStringSlice(ko.Spec.ResourceARNs))
+	ko.Status.ManagedSources = managedEntities(ko, ko.Spec.Sources, nil, aws.ToStringSlice(ko.Spec.Sources))
+}
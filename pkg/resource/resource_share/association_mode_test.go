@@ -0,0 +1,271 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+func TestAssociationChanges(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             string
+		desired          []*string
+		latest           []*string
+		managed          []*string
+		fromAssociations []*string
+		wantToAdd        []string
+		wantToDelete     []string
+	}{
+		{
+			name:    "nil desired list leaves the associations alone",
+			desired: nil,
+			latest:  aws.StringSlice([]string{"111122223333"}),
+		},
+		{
+			name:         "empty desired list disassociates everything",
+			desired:      []*string{},
+			latest:       aws.StringSlice([]string{"111122223333", "444455556666"}),
+			wantToAdd:    []string{},
+			wantToDelete: []string{"111122223333", "444455556666"},
+		},
+		{
+			name:         "desired list is applied",
+			desired:      aws.StringSlice([]string{"111122223333", "777788889999"}),
+			latest:       aws.StringSlice([]string{"111122223333", "444455556666"}),
+			wantToAdd:    []string{"777788889999"},
+			wantToDelete: []string{"444455556666"},
+		},
+		{
+			name:             "entities of ResourceShareAssociation resources are kept",
+			desired:          []*string{},
+			latest:           aws.StringSlice([]string{"111122223333", "444455556666"}),
+			fromAssociations: aws.StringSlice([]string{"444455556666"}),
+			wantToAdd:        []string{},
+			wantToDelete:     []string{"111122223333"},
+		},
+		{
+			name:         "authoritative mode disassociates unmanaged entities",
+			mode:         associationModeAuthoritative,
+			desired:      aws.StringSlice([]string{"111122223333"}),
+			latest:       aws.StringSlice([]string{"111122223333", "444455556666"}),
+			managed:      aws.StringSlice([]string{"111122223333"}),
+			wantToAdd:    []string{},
+			wantToDelete: []string{"444455556666"},
+		},
+		{
+			name:         "additive mode only disassociates managed entities",
+			mode:         associationModeAdditive,
+			desired:      aws.StringSlice([]string{"777788889999"}),
+			latest:       aws.StringSlice([]string{"111122223333", "444455556666"}),
+			managed:      aws.StringSlice([]string{"111122223333"}),
+			wantToAdd:    []string{"777788889999"},
+			wantToDelete: []string{"111122223333"},
+		},
+		{
+			name:             "additive mode keeps entities of ResourceShareAssociation resources",
+			mode:             associationModeAdditive,
+			desired:          []*string{},
+			latest:           aws.StringSlice([]string{"111122223333"}),
+			managed:          aws.StringSlice([]string{"111122223333"}),
+			fromAssociations: aws.StringSlice([]string{"111122223333"}),
+			wantToAdd:        []string{},
+			wantToDelete:     []string{},
+		},
+		{
+			name:    "additive mode with a nil desired list",
+			mode:    associationModeAdditive,
+			desired: nil,
+			latest:  aws.StringSlice([]string{"111122223333"}),
+			managed: aws.StringSlice([]string{"111122223333"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{}
+			if tt.mode != "" {
+				ko.Spec.AssociationMode = aws.String(tt.mode)
+			}
+			toAdd, toDelete := associationChanges(ko, tt.desired, tt.latest, tt.managed, tt.fromAssociations)
+			if !reflect.DeepEqual(toAdd, tt.wantToAdd) {
+				t.Errorf("associationChanges() toAdd = %v, want %v", toAdd, tt.wantToAdd)
+			}
+			if !reflect.DeepEqual(toDelete, tt.wantToDelete) {
+				t.Errorf("associationChanges() toDelete = %v, want %v", toDelete, tt.wantToDelete)
+			}
+		})
+	}
+}
+
+func TestValidateAssociationMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    *string
+		wantErr bool
+	}{
+		{name: "unset"},
+		{name: "authoritative", mode: aws.String(associationModeAuthoritative)},
+		{name: "additive", mode: aws.String(associationModeAdditive)},
+		{name: "empty", mode: aws.String("")},
+		{name: "unknown", mode: aws.String("Exclusive"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resource{&svcapitypes.ResourceShare{
+				Spec: svcapitypes.ResourceShareSpec{AssociationMode: tt.mode},
+			}}
+			if err := validateAssociationMode(r); (err != nil) != tt.wantErr {
+				t.Errorf("validateAssociationMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManagedEntities(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		desired []*string
+		managed []*string
+		added   []string
+		want    []*string
+	}{
+		{
+			name:    "authoritative mode doesn't track managed entities",
+			mode:    associationModeAuthoritative,
+			desired: aws.StringSlice([]string{"111122223333"}),
+			added:   []string{"111122223333"},
+			want:    nil,
+		},
+		{
+			name:    "nil desired list keeps the managed entities",
+			mode:    associationModeAdditive,
+			managed: aws.StringSlice([]string{"111122223333"}),
+			want:    aws.StringSlice([]string{"111122223333"}),
+		},
+		{
+			name:    "added entities become managed",
+			mode:    associationModeAdditive,
+			desired: aws.StringSlice([]string{"111122223333", "444455556666"}),
+			managed: aws.StringSlice([]string{"111122223333"}),
+			added:   []string{"444455556666"},
+			want:    aws.StringSlice([]string{"111122223333", "444455556666"}),
+		},
+		{
+			name:    "entities no longer desired stop being managed",
+			mode:    associationModeAdditive,
+			desired: aws.StringSlice([]string{"444455556666"}),
+			managed: aws.StringSlice([]string{"111122223333", "444455556666"}),
+			want:    aws.StringSlice([]string{"444455556666"}),
+		},
+		{
+			name:    "entities associated outside of the controller aren't managed",
+			mode:    associationModeAdditive,
+			desired: aws.StringSlice([]string{"111122223333", "777788889999"}),
+			managed: aws.StringSlice([]string{"111122223333"}),
+			want:    aws.StringSlice([]string{"111122223333"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{
+				Spec: svcapitypes.ResourceShareSpec{AssociationMode: aws.String(tt.mode)},
+			}
+			got := managedEntities(ko, tt.desired, tt.managed, tt.added)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("managedEntities() = %v, want %v", aws.ToStringSlice(got), aws.ToStringSlice(tt.want))
+			}
+		})
+	}
+}
+
+func TestAssociatesWith(t *testing.T) {
+	arn := ackv1alpha1.AWSResourceName("arn:aws:ram:us-west-2:111122223333:resource-share/share")
+	ko := &svcapitypes.ResourceShare{
+		ObjectMeta: metav1.ObjectMeta{Name: "share", Namespace: "team"},
+		Status: svcapitypes.ResourceShareStatus{
+			ACKResourceMetadata: &ackv1alpha1.ResourceMetadata{ARN: &arn},
+		},
+	}
+	tests := []struct {
+		name        string
+		namespace   string
+		spec        svcapitypes.ResourceShareAssociationSpec
+		wantMatches bool
+	}{
+		{
+			name:      "reference in the same namespace",
+			namespace: "team",
+			spec: svcapitypes.ResourceShareAssociationSpec{
+				ResourceShareRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+					From: &ackv1alpha1.AWSResourceReference{Name: aws.String("share")},
+				},
+			},
+			wantMatches: true,
+		},
+		{
+			name:      "reference to another namespace",
+			namespace: "other",
+			spec: svcapitypes.ResourceShareAssociationSpec{
+				ResourceShareRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+					From: &ackv1alpha1.AWSResourceReference{Name: aws.String("share"), Namespace: aws.String("team")},
+				},
+			},
+			wantMatches: true,
+		},
+		{
+			name:      "reference to a share of the same name in another namespace",
+			namespace: "other",
+			spec: svcapitypes.ResourceShareAssociationSpec{
+				ResourceShareRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+					From: &ackv1alpha1.AWSResourceReference{Name: aws.String("share")},
+				},
+			},
+			wantMatches: false,
+		},
+		{
+			name:        "resource share ARN",
+			namespace:   "other",
+			spec:        svcapitypes.ResourceShareAssociationSpec{ResourceShareARN: aws.String(string(arn))},
+			wantMatches: true,
+		},
+		{
+			name:      "another resource share ARN",
+			namespace: "team",
+			spec: svcapitypes.ResourceShareAssociationSpec{
+				ResourceShareARN: aws.String("arn:aws:ram:us-west-2:111122223333:resource-share/other"),
+			},
+			wantMatches: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			association := &svcapitypes.ResourceShareAssociation{
+				ObjectMeta: metav1.ObjectMeta{Name: "association", Namespace: tt.namespace},
+				Spec:       tt.spec,
+			}
+			if got := associatesWith(association, ko); got != tt.wantMatches {
+				t.Errorf("associatesWith() = %v, want %v", got, tt.wantMatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"context"
+	"strings"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+)
+
+// setDefaultPermissions sets the PermissionARNs of a resource share that
+// doesn't specify any to the AWS managed permissions RAM attaches by default
+// for the types of its resources. Recording them in the spec keeps RAM from
+// attaching them silently, which would make the permissions read back
+// differ from the spec. Resource types that can't be matched with a default
+// permission are left for RAM to resolve.
+func (rm *resourceManager) setDefaultPermissions(
+	ctx context.Context,
+	r *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.setDefaultPermissions")
+	defer func() {
+		exit(err)
+	}()
+	if len(r.ko.Spec.PermissionARNs) > 0 || len(r.ko.Spec.ResourceARNs) == 0 {
+		return nil
+	}
+	defaults, err := rm.listDefaultPermissions(ctx)
+	if err != nil {
+		return err
+	}
+	if unmatched := appendDefaultPermissions(r.ko, defaults, r.ko.Spec.ResourceARNs); len(unmatched) > 0 {
+		rlog.Debug("no default permission found for the type of some resources", "resourceArns", unmatched)
+	}
+	return nil
+}
+
+// addDefaultPermissions adds to the PermissionARNs of the desired resource
+// share the AWS managed permissions RAM attaches by default for the types
+// of resources the latest resource share doesn't have yet, as RAM attaches
+// them when the resources are associated.
+func (rm *resourceManager) addDefaultPermissions(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.addDefaultPermissions")
+	defer func() {
+		exit(err)
+	}()
+	added := resourcesNeedingDefaultPermissions(desired.ko, latest.ko)
+	if len(added) == 0 {
+		return nil
+	}
+	defaults, err := rm.listDefaultPermissions(ctx)
+	if err != nil {
+		return err
+	}
+	if unmatched := appendDefaultPermissions(desired.ko, defaults, added); len(unmatched) > 0 {
+		rlog.Debug("no default permission found for the type of some resources", "resourceArns", unmatched)
+	}
+	return nil
+}
+
+// resourcesNeedingDefaultPermissions returns the desired resources whose
+// type none of the latest resources has. There are none when the desired
+// PermissionARNs add other permissions, which then are the ones chosen for
+// the new resources.
+func resourcesNeedingDefaultPermissions(
+	desired *svcapitypes.ResourceShare,
+	latest *svcapitypes.ResourceShare,
+) []*string {
+	if toAdd, _ := compareStringSlices(desired.Spec.PermissionARNs, latest.Spec.PermissionARNs); len(toAdd) > 0 {
+		return nil
+	}
+	types := map[string]bool{}
+	for _, resourceARN := range latest.Spec.ResourceARNs {
+		if key, ok := resourceARNTypeKey(aws.ToString(resourceARN)); ok {
+			types[key] = true
+		}
+	}
+	added := []*string{}
+	for _, resourceARN := range desired.Spec.ResourceARNs {
+		if key, ok := resourceARNTypeKey(aws.ToString(resourceARN)); !ok || !types[key] {
+			added = append(added, resourceARN)
+		}
+	}
+	return added
+}
+
+// appendDefaultPermissions appends to the PermissionARNs of the resource
+// share the supplied default permissions of the types of the supplied
+// resources it doesn't have yet, and returns the resources whose type has
+// no default permission.
+func appendDefaultPermissions(
+	ko *svcapitypes.ResourceShare,
+	defaults map[string]string,
+	resourceARNs []*string,
+) (unmatched []string) {
+	for _, resourceARN := range resourceARNs {
+		key, ok := resourceARNTypeKey(aws.ToString(resourceARN))
+		if !ok || defaults[key] == "" {
+			unmatched = append(unmatched, aws.ToString(resourceARN))
+			continue
+		}
+		if !ackutil.InStrings(defaults[key], aws.ToStringSlice(ko.Spec.PermissionARNs)) {
+			ko.Spec.PermissionARNs = append(ko.Spec.PermissionARNs, aws.String(defaults[key]))
+		}
+	}
+	return unmatched
+}
+
+// listDefaultPermissions returns the ARNs of the AWS managed permissions RAM
+// attaches by default, keyed by the resourceTypeKey of their resource type.
+func (rm *resourceManager) listDefaultPermissions(
+	ctx context.Context,
+) (map[string]string, error) {
+	input := &svcsdk.ListPermissionsInput{
+		PermissionType: svcsdktypes.PermissionTypeFilterAwsManaged,
+		MaxResults:     listPageSize(),
+	}
+	defaults := map[string]string{}
+	for page := 1; ; page++ {
+		resp, err := rm.sdkapi.ListPermissions(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListPermissions", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range resp.Permissions {
+			if !aws.ToBool(p.IsResourceTypeDefault) || p.Arn == nil || p.ResourceType == nil {
+				continue
+			}
+			defaults[resourceTypeKey(*p.ResourceType)] = *p.Arn
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		if page >= svcconfig.ListMaxPages {
+			return nil, listLimitExceeded("ListPermissions")
+		}
+		input.NextToken = resp.NextToken
+	}
+	return defaults, nil
+}
+
+// resourceTypeKey returns the key a RAM resource type, such as
+// `ec2:TransitGateway`, is matched with the type of a resource ARN by.
+func resourceTypeKey(resourceType string) string {
+	service, typ, _ := strings.Cut(resourceType, ":")
+	return strings.ToLower(service) + ":" + strings.ToLower(strings.ReplaceAll(typ, "-", ""))
+}
+
+// resourceARNTypeKey returns the resourceTypeKey of the type of the supplied
+// resource ARN, such as `ec2:transitgateway` for the ARN of a transit
+// gateway, which is the part of its resource before the first slash or
+// colon.
+func resourceARNTypeKey(resourceARN string) (string, bool) {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil || parsed.Resource == "" {
+		return "", false
+	}
+	typ := parsed.Resource
+	if i := strings.IndexAny(typ, "/:"); i >= 0 {
+		typ = typ[:i]
+	}
+	return resourceTypeKey(parsed.Service + ":" + typ), true
+}
@@ -0,0 +1,154 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+const (
+	subnetARN        = "arn:aws:ec2:us-west-2:111111111111:subnet/subnet-1"
+	otherSubnetARN   = "arn:aws:ec2:us-west-2:111111111111:subnet/subnet-2"
+	prefixListARN    = "arn:aws:ec2:us-west-2:111111111111:prefix-list/pl-1"
+	ruleGroupARN     = "arn:aws:route53resolver:us-west-2:111111111111:firewall-rule-group/rslvr-frg-1"
+	subnetPermission = "arn:aws:ram::aws:permission/AWSRAMDefaultPermissionSubnet"
+	prefixPermission = "arn:aws:ram::aws:permission/AWSRAMDefaultPermissionPrefixList"
+	customPermission = "arn:aws:ram:us-west-2:111111111111:permission/custom"
+)
+
+var defaultPermissions = map[string]string{
+	resourceTypeKey("ec2:Subnet"):     subnetPermission,
+	resourceTypeKey("ec2:PrefixList"): prefixPermission,
+}
+
+func TestAppendDefaultPermissions(t *testing.T) {
+	tests := []struct {
+		name          string
+		permissions   []string
+		resources     []string
+		want          []string
+		wantUnmatched []string
+	}{
+		{
+			name:      "one permission per resource type",
+			resources: []string{subnetARN, otherSubnetARN, prefixListARN},
+			want:      []string{subnetPermission, prefixPermission},
+		},
+		{
+			name:          "resource type without a default permission",
+			resources:     []string{subnetARN, ruleGroupARN},
+			want:          []string{subnetPermission},
+			wantUnmatched: []string{ruleGroupARN},
+		},
+		{
+			name:          "no resource type with a default permission",
+			resources:     []string{ruleGroupARN},
+			want:          []string{},
+			wantUnmatched: []string{ruleGroupARN},
+		},
+		{
+			name:        "default permission already present",
+			permissions: []string{customPermission, subnetPermission},
+			resources:   []string{subnetARN, prefixListARN},
+			want:        []string{customPermission, subnetPermission, prefixPermission},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{}
+			if tt.permissions != nil {
+				ko.Spec.PermissionARNs = aws.StringSlice(tt.permissions)
+			}
+			unmatched := appendDefaultPermissions(ko, defaultPermissions, aws.StringSlice(tt.resources))
+			if got := aws.ToStringSlice(ko.Spec.PermissionARNs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PermissionARNs = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(unmatched, tt.wantUnmatched) {
+				t.Errorf("appendDefaultPermissions() = %v, want %v", unmatched, tt.wantUnmatched)
+			}
+		})
+	}
+}
+
+func TestAddDefaultPermissionsOnUpdate(t *testing.T) {
+	tests := []struct {
+		name              string
+		desiredResources  []string
+		desiredPermission []string
+		latestResources   []string
+		latestPermission  []string
+		want              []string
+	}{
+		{
+			name:              "resource of an existing type",
+			desiredResources:  []string{subnetARN, otherSubnetARN},
+			desiredPermission: []string{subnetPermission},
+			latestResources:   []string{subnetARN},
+			latestPermission:  []string{subnetPermission},
+			want:              []string{subnetPermission},
+		},
+		{
+			name:              "resource of a new type",
+			desiredResources:  []string{subnetARN, prefixListARN},
+			desiredPermission: []string{subnetPermission},
+			latestResources:   []string{subnetARN},
+			latestPermission:  []string{subnetPermission},
+			want:              []string{subnetPermission, prefixPermission},
+		},
+		{
+			name:              "resource of a new type with a permission chosen for it",
+			desiredResources:  []string{subnetARN, prefixListARN},
+			desiredPermission: []string{subnetPermission, customPermission},
+			latestResources:   []string{subnetARN},
+			latestPermission:  []string{subnetPermission},
+			want:              []string{subnetPermission, customPermission},
+		},
+		{
+			name:              "resource of a new type without a default permission",
+			desiredResources:  []string{subnetARN, ruleGroupARN},
+			desiredPermission: []string{subnetPermission},
+			latestResources:   []string{subnetARN},
+			latestPermission:  []string{subnetPermission},
+			want:              []string{subnetPermission},
+		},
+		{
+			name:             "first resources of a resource share without permissions",
+			desiredResources: []string{subnetARN, ruleGroupARN},
+			want:             []string{subnetPermission},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &svcapitypes.ResourceShare{Spec: svcapitypes.ResourceShareSpec{
+				PermissionARNs: aws.StringSlice(tt.desiredPermission),
+				ResourceARNs:   aws.StringSlice(tt.desiredResources),
+			}}
+			latest := &svcapitypes.ResourceShare{Spec: svcapitypes.ResourceShareSpec{
+				PermissionARNs: aws.StringSlice(tt.latestPermission),
+				ResourceARNs:   aws.StringSlice(tt.latestResources),
+			}}
+			if added := resourcesNeedingDefaultPermissions(desired, latest); len(added) > 0 {
+				appendDefaultPermissions(desired, defaultPermissions, added)
+			}
+			if got := aws.ToStringSlice(desired.Spec.PermissionARNs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PermissionARNs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
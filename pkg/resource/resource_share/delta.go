@@ -41,7 +41,7 @@ func newResourceDelta(
 		delta.Add("", a, b)
 		return delta
 	}
-	compareTags(delta, a, b)
+	customPreCompare(delta, a, b)
 
 	if ackcompare.HasNilDifference(a.ko.Spec.AllowExternalPrincipals, b.ko.Spec.AllowExternalPrincipals) {
 		delta.Add("Spec.AllowExternalPrincipals", a.ko.Spec.AllowExternalPrincipals, b.ko.Spec.AllowExternalPrincipals)
@@ -50,6 +50,13 @@ func newResourceDelta(
 			delta.Add("Spec.AllowExternalPrincipals", a.ko.Spec.AllowExternalPrincipals, b.ko.Spec.AllowExternalPrincipals)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.AssociationMode, b.ko.Spec.AssociationMode) {
+		delta.Add("Spec.AssociationMode", a.ko.Spec.AssociationMode, b.ko.Spec.AssociationMode)
+	} else if a.ko.Spec.AssociationMode != nil && b.ko.Spec.AssociationMode != nil {
+		if *a.ko.Spec.AssociationMode != *b.ko.Spec.AssociationMode {
+			delta.Add("Spec.AssociationMode", a.ko.Spec.AssociationMode, b.ko.Spec.AssociationMode)
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.Name, b.ko.Spec.Name) {
 		delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 	} else if a.ko.Spec.Name != nil && b.ko.Spec.Name != nil {
@@ -67,26 +74,17 @@ func newResourceDelta(
 	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.PermissionRefs, b.ko.Spec.PermissionRefs) {
 		delta.Add("Spec.PermissionRefs", a.ko.Spec.PermissionRefs, b.ko.Spec.PermissionRefs)
 	}
-	if len(a.ko.Spec.Principals) != len(b.ko.Spec.Principals) {
-		delta.Add("Spec.Principals", a.ko.Spec.Principals, b.ko.Spec.Principals)
-	} else if len(a.ko.Spec.Principals) > 0 {
-		if !ackcompare.SliceStringPEqual(a.ko.Spec.Principals, b.ko.Spec.Principals) {
-			delta.Add("Spec.Principals", a.ko.Spec.Principals, b.ko.Spec.Principals)
-		}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.PrincipalRefs, b.ko.Spec.PrincipalRefs) {
+		delta.Add("Spec.PrincipalRefs", a.ko.Spec.PrincipalRefs, b.ko.Spec.PrincipalRefs)
 	}
-	if len(a.ko.Spec.ResourceARNs) != len(b.ko.Spec.ResourceARNs) {
-		delta.Add("Spec.ResourceARNs", a.ko.Spec.ResourceARNs, b.ko.Spec.ResourceARNs)
-	} else if len(a.ko.Spec.ResourceARNs) > 0 {
-		if !ackcompare.SliceStringPEqual(a.ko.Spec.ResourceARNs, b.ko.Spec.ResourceARNs) {
-			delta.Add("Spec.ResourceARNs", a.ko.Spec.ResourceARNs, b.ko.Spec.ResourceARNs)
-		}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.PrincipalSetRefs, b.ko.Spec.PrincipalSetRefs) {
+		delta.Add("Spec.PrincipalSetRefs", a.ko.Spec.PrincipalSetRefs, b.ko.Spec.PrincipalSetRefs)
 	}
-	if len(a.ko.Spec.Sources) != len(b.ko.Spec.Sources) {
-		delta.Add("Spec.Sources", a.ko.Spec.Sources, b.ko.Spec.Sources)
-	} else if len(a.ko.Spec.Sources) > 0 {
-		if !ackcompare.SliceStringPEqual(a.ko.Spec.Sources, b.ko.Spec.Sources) {
-			delta.Add("Spec.Sources", a.ko.Spec.Sources, b.ko.Spec.Sources)
-		}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.ResourceRefs, b.ko.Spec.ResourceRefs) {
+		delta.Add("Spec.ResourceRefs", a.ko.Spec.ResourceRefs, b.ko.Spec.ResourceRefs)
+	}
+	if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.ResourceSelectors, b.ko.Spec.ResourceSelectors) {
+		delta.Add("Spec.ResourceSelectors", a.ko.Spec.ResourceSelectors, b.ko.Spec.ResourceSelectors)
 	}
 
 	return delta
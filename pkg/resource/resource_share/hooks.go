@@ -15,16 +15,137 @@ package resource_share
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+	"github.com/aws-controllers-k8s/ram-controller/pkg/idempotency"
 )
 
+// conditionTypeAssociationsReady is the type of the condition reporting
+// whether every principal and resource is associated with the resource share.
+const conditionTypeAssociationsReady ackv1alpha1.ConditionType = "AssociationsReady"
+
+// listPageSize returns the MaxResults of the RAM list calls.
+func listPageSize() *int32 {
+	return aws.Int32(svcconfig.ListPageSize)
+}
+
+// listLimitExceeded returns the terminal error reported when the supplied
+// RAM list operation has more pages than the controller is allowed to read.
+// It is reported in the ACK.Terminal condition rather than retried, since
+// reading the resource share again would hit the same limit.
+func listLimitExceeded(operation string) error {
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"%s returned more than %d pages of %d items, increase --%s to manage this resource share",
+		operation, svcconfig.ListMaxPages, svcconfig.ListPageSize,
+		svcconfig.FlagListMaxPages,
+	))
+}
+
+// getRemainingResourceShares follows the NextToken of the supplied
+// GetResourceShares response and appends the resource shares of the
+// following pages to it.
+func (rm *resourceManager) getRemainingResourceShares(
+	ctx context.Context,
+	input *svcsdk.GetResourceSharesInput,
+	resp *svcsdk.GetResourceSharesOutput,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.getRemainingResourceShares")
+	defer func() {
+		exit(err)
+	}()
+	for page := 1; resp.NextToken != nil; page++ {
+		if page >= svcconfig.ListMaxPages {
+			return listLimitExceeded("GetResourceShares")
+		}
+		input.NextToken = resp.NextToken
+		var next *svcsdk.GetResourceSharesOutput
+		next, err = rm.sdkapi.GetResourceShares(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "GetResourceShares", err)
+		if err != nil {
+			return err
+		}
+		resp.ResourceShares = append(resp.ResourceShares, next.ResourceShares...)
+		resp.NextToken = next.NextToken
+	}
+	return nil
+}
+
+// clientToken returns the client token of the supplied call made on behalf
+// of the resource.
+func clientToken(r *resource, operation string, params ...[]string) *string {
+	return idempotency.ClientToken(r.ko, r.ko.Status.ClientTokenNonce, operation, params...)
+}
+
+// bumpClientTokenNonce bumps the nonce of the client tokens of the resource
+// once the calls made with the current one succeeded.
+func bumpClientTokenNonce(ko *svcapitypes.ResourceShare) {
+	ko.Status.ClientTokenNonce = idempotency.NextNonce(ko.Status.ClientTokenNonce)
+}
+
+// resourceShareARN returns the ARN of the resource share, or an empty
+// string if the resource share hasn't been created or adopted yet.
+func resourceShareARN(ko *svcapitypes.ResourceShare) string {
+	if ko.Status.ACKResourceMetadata == nil || ko.Status.ACKResourceMetadata.ARN == nil {
+		return ""
+	}
+	return string(*ko.Status.ACKResourceMetadata.ARN)
+}
+
+// selectResourceShares narrows the resource shares returned by
+// GetResourceShares down to the one the resource manages. Once the ARN is
+// known only the resource share with that ARN is kept. Before that the
+// resource share is looked up by name, and RAM allows several resource
+// shares with the same name: a terminal error is returned when more than
+// one of them is ACTIVE rather than binding the resource to an arbitrary
+// one.
+func selectResourceShares(
+	ko *svcapitypes.ResourceShare,
+	shares []svcsdktypes.ResourceShare,
+) ([]svcsdktypes.ResourceShare, error) {
+	if arn := resourceShareARN(ko); arn != "" {
+		selected := []svcsdktypes.ResourceShare{}
+		for _, share := range shares {
+			if aws.ToString(share.ResourceShareArn) == arn {
+				selected = append(selected, share)
+			}
+		}
+		return selected, nil
+	}
+	active := []svcsdktypes.ResourceShare{}
+	arns := []string{}
+	for _, share := range shares {
+		if share.Status == svcsdktypes.ResourceShareStatusActive {
+			active = append(active, share)
+			arns = append(arns, aws.ToString(share.ResourceShareArn))
+		}
+	}
+	switch {
+	case len(active) > 1:
+		return nil, ackerr.NewTerminalError(fmt.Errorf(
+			"ambiguous name: %d ACTIVE resource shares are named %q: %s",
+			len(active), aws.ToString(ko.Spec.Name), strings.Join(arns, ", "),
+		))
+	case len(active) == 1:
+		return active, nil
+	}
+	return shares, nil
+}
+
 // syncTags used to keep tags in sync by calling Create and Delete API's
 func (rm *resourceManager) syncTags(
 	ctx context.Context,
@@ -98,6 +219,38 @@ func (rm *resourceManager) sdkTags(
 	return sdktags
 }
 
+// customPreCompare compares the fields of the resource share whose
+// comparison is ignored by the generated delta code.
+func customPreCompare(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	compareTags(delta, a, b)
+	compareAssociations(delta, a, b)
+	comparePromotion(delta, a, b)
+}
+
+// compareAssociations compares the principals, the resources and the sources
+// associated with the resource share regardless of their order, following
+// the rules of associationChanges: only the entities the resource share
+// would associate or disassociate make a difference.
+func compareAssociations(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if toAdd, toDelete := associationChanges(a.ko, a.ko.Spec.Principals, b.ko.Spec.Principals, b.ko.Status.ManagedPrincipals, a.ko.Status.PrincipalsFromAssociations); len(toAdd)+len(toDelete) > 0 {
+		delta.Add("Spec.Principals", a.ko.Spec.Principals, b.ko.Spec.Principals)
+	}
+	if toAdd, toDelete := associationChanges(a.ko, a.ko.Spec.ResourceARNs, b.ko.Spec.ResourceARNs, b.ko.Status.ManagedResourceARNs, a.ko.Status.ResourceARNsFromAssociations); len(toAdd)+len(toDelete) > 0 {
+		delta.Add("Spec.ResourceARNs", a.ko.Spec.ResourceARNs, b.ko.Spec.ResourceARNs)
+	}
+	if toAdd, toDelete := associationChanges(a.ko, a.ko.Spec.Sources, b.ko.Spec.Sources, b.ko.Status.ManagedSources, nil); len(toAdd)+len(toDelete) > 0 {
+		delta.Add("Spec.Sources", a.ko.Spec.Sources, b.ko.Spec.Sources)
+	}
+}
+
 // compareTags is a custom comparison function for comparing lists of Tag
 // structs where the order of the structs in the list is not important.
 func compareTags(
@@ -148,6 +301,7 @@ func (rm *resourceManager) syncPermissions(
 				&svcsdk.DisassociateResourceSharePermissionInput{
 					ResourceShareArn: (*string)(resourceArn),
 					PermissionArn:    &permission,
+					ClientToken:      clientToken(desired, "DisassociateResourceSharePermission", []string{permission}),
 				},
 			)
 			rm.metrics.RecordAPICall("UPDATE", "DisassociateResourceSharePermission", err)
@@ -165,6 +319,7 @@ func (rm *resourceManager) syncPermissions(
 				&svcsdk.AssociateResourceSharePermissionInput{
 					ResourceShareArn: (*string)(resourceArn),
 					PermissionArn:    &permission,
+					ClientToken:      clientToken(desired, "AssociateResourceSharePermission", []string{permission}),
 				},
 			)
 			rm.metrics.RecordAPICall("UPDATE", "AssociateResourceSharePermission", err)
@@ -200,7 +355,7 @@ func compareStringSlices(a, b []*string) ([]string, []string) {
 
 	for _, v := range a {
 		if _, ok := bm[*v]; !ok {
-			toAdd = append(toDelete, *v)
+			toAdd = append(toAdd, *v)
 		}
 	}
 
@@ -216,22 +371,33 @@ func (rm *resourceManager) getPermissionArns(ctx context.Context, r *resource) (
 	if r == nil || r.ko == nil || r.ko.Status.ACKResourceMetadata == nil || r.ko.Status.ACKResourceMetadata.ARN == nil {
 		return nil
 	}
-	resp, err := rm.sdkapi.ListResourceSharePermissions(
-		ctx,
-		&svcsdk.ListResourceSharePermissionsInput{
-			ResourceShareArn: (*string)(r.ko.Status.ACKResourceMetadata.ARN),
-		},
-	)
-	rm.metrics.RecordAPICall("READ_MANY", "ListResourceSharePermissions", err)
-	if err != nil {
-		return err
+	input := &svcsdk.ListResourceSharePermissionsInput{
+		ResourceShareArn: (*string)(r.ko.Status.ACKResourceMetadata.ARN),
+		MaxResults:       listPageSize(),
 	}
-
-	if resp.Permissions != nil {
-		permissionArns := make([]*string, 0, len(resp.Permissions))
+	var permissionArns []*string
+	for page := 1; ; page++ {
+		var resp *svcsdk.ListResourceSharePermissionsOutput
+		resp, err = rm.sdkapi.ListResourceSharePermissions(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListResourceSharePermissions", err)
+		if err != nil {
+			return err
+		}
+		if resp.Permissions != nil && permissionArns == nil {
+			permissionArns = make([]*string, 0, len(resp.Permissions))
+		}
 		for _, p := range resp.Permissions {
 			permissionArns = append(permissionArns, p.Arn)
 		}
+		if resp.NextToken == nil {
+			break
+		}
+		if page >= svcconfig.ListMaxPages {
+			return listLimitExceeded("ListResourceSharePermissions")
+		}
+		input.NextToken = resp.NextToken
+	}
+	if permissionArns != nil {
 		r.ko.Spec.PermissionARNs = permissionArns
 	}
 
@@ -251,18 +417,21 @@ func (rm *resourceManager) syncResourceShareResources(
 
 	resourceShareArn := latest.ko.Status.ACKResourceMetadata.ARN
 
-	desiredPrincipals := desired.ko.Spec.Principals
-	latestPrincipals := latest.ko.Spec.Principals
-
-	desiredResourceArns := desired.ko.Spec.ResourceARNs
-	latestResourceArns := latest.ko.Spec.ResourceARNs
-
-	desiredSources := desired.ko.Spec.Sources
-	latestSources := latest.ko.Spec.Sources
-
-	toAddPrincipals, toDeletePrincipals := compareStringSlices(desiredPrincipals, latestPrincipals)
-	toAddResources, toDeleteResources := compareStringSlices(desiredResourceArns, latestResourceArns)
-	toAddSources, toDeleteSources := compareStringSlices(desiredSources, latestSources)
+	// Principals and resources are left to ResourceShareAssociation resources
+	// when the desired resource share doesn't list them, and the ones these
+	// associate are never disassociated. In the Additive mode, the entities
+	// the controller didn't associate are left untouched too.
+	toAddPrincipals, toDeletePrincipals := associationChanges(
+		desired.ko, desired.ko.Spec.Principals, latest.ko.Spec.Principals,
+		latest.ko.Status.ManagedPrincipals, desired.ko.Status.PrincipalsFromAssociations,
+	)
+	toAddResources, toDeleteResources := associationChanges(
+		desired.ko, desired.ko.Spec.ResourceARNs, latest.ko.Spec.ResourceARNs,
+		latest.ko.Status.ManagedResourceARNs, desired.ko.Status.ResourceARNsFromAssociations,
+	)
+	toAddSources, toDeleteSources := associationChanges(
+		desired.ko, desired.ko.Spec.Sources, latest.ko.Spec.Sources, latest.ko.Status.ManagedSources, nil,
+	)
 
 	if len(toDeletePrincipals)+len(toDeleteResources)+len(toDeleteSources) > 0 {
 		rlog.Debug("disassociationg resources from ResourceShare")
@@ -273,6 +442,10 @@ func (rm *resourceManager) syncResourceShareResources(
 				Principals:       toDeletePrincipals,
 				ResourceArns:     toDeleteResources,
 				Sources:          toDeleteSources,
+				ClientToken: clientToken(
+					desired, "DisassociateResourceShare",
+					toDeletePrincipals, toDeleteResources, toDeleteSources,
+				),
 			},
 		)
 		rm.metrics.RecordAPICall("UPDATE", "DisassociateResourceShare", err)
@@ -290,6 +463,10 @@ func (rm *resourceManager) syncResourceShareResources(
 				Principals:       toAddPrincipals,
 				ResourceArns:     toAddResources,
 				Sources:          toAddSources,
+				ClientToken: clientToken(
+					desired, "AssociateResourceShare",
+					toAddPrincipals, toAddResources, toAddSources,
+				),
 			},
 		)
 		rm.metrics.RecordAPICall("UPDATE", "AssociateResourceShare", err)
@@ -298,6 +475,15 @@ func (rm *resourceManager) syncResourceShareResources(
 		}
 	}
 
+	desired.ko.Status.ManagedPrincipals = managedEntities(
+		desired.ko, desired.ko.Spec.Principals, latest.ko.Status.ManagedPrincipals, toAddPrincipals,
+	)
+	desired.ko.Status.ManagedResourceARNs = managedEntities(
+		desired.ko, desired.ko.Spec.ResourceARNs, latest.ko.Status.ManagedResourceARNs, toAddResources,
+	)
+	desired.ko.Status.ManagedSources = managedEntities(
+		desired.ko, desired.ko.Spec.Sources, latest.ko.Status.ManagedSources, toAddSources,
+	)
 	return nil
 }
 
@@ -314,11 +500,18 @@ func (rm *resourceManager) getResourceShareAssociations(
 		return nil
 	}
 	resourceArn := r.ko.Status.ACKResourceMetadata.ARN
-	r.ko.Spec.Principals, err = rm.setResourceShareAssociation(ctx, svcsdktypes.ResourceShareAssociationTypePrincipal, *((*string)(resourceArn)))
+	principals, err := rm.listResourceShareAssociations(ctx, svcsdktypes.ResourceShareAssociationTypePrincipal, *((*string)(resourceArn)))
 	if err != nil {
 		return err
 	}
-	r.ko.Spec.ResourceARNs, err = rm.setResourceShareAssociation(ctx, svcsdktypes.ResourceShareAssociationTypeResource, *((*string)(resourceArn)))
+	resources, err := rm.listResourceShareAssociations(ctx, svcsdktypes.ResourceShareAssociationTypeResource, *((*string)(resourceArn)))
+	if err != nil {
+		return err
+	}
+	r.ko.Spec.Principals = associatedEntities(principals)
+	r.ko.Spec.ResourceARNs = associatedEntities(resources)
+	setAssociationsStatus(r.ko, principals, resources)
+	r.ko.Spec.Sources, err = rm.getSourceAssociations(ctx, *((*string)(resourceArn)))
 	if err != nil {
 		return err
 	}
@@ -326,33 +519,243 @@ func (rm *resourceManager) getResourceShareAssociations(
 	return nil
 }
 
-func (rm *resourceManager) setResourceShareAssociation(
+// refreshAssociationsStatus reads the associations of the resource share
+// again and reports them in the status of the supplied resource, without
+// touching its Spec. It is used after the associations were modified.
+func (rm *resourceManager) refreshAssociationsStatus(
 	ctx context.Context,
-	resresourceType svcsdktypes.ResourceShareAssociationType,
+	r *resource,
 	resourceArn string,
-) (slices []*string, err error) {
-
-	resp, err := rm.sdkapi.GetResourceShareAssociations(
-		ctx,
-		&svcsdk.GetResourceShareAssociationsInput{
-			AssociationType:   resresourceType,
-			ResourceShareArns: []string{resourceArn},
-		},
-	)
-
-	slices = make([]*string, 0)
-	rm.metrics.RecordAPICall("READ_MANY", "GetResourceShareAssociations", err)
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.refreshAssociationsStatus")
+	defer func() {
+		exit(err)
+	}()
+	if resourceArn == "" {
+		return nil
+	}
+	principals, err := rm.listResourceShareAssociations(ctx, svcsdktypes.ResourceShareAssociationTypePrincipal, resourceArn)
+	if err != nil {
+		return err
+	}
+	resources, err := rm.listResourceShareAssociations(ctx, svcsdktypes.ResourceShareAssociationTypeResource, resourceArn)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	setAssociationsStatus(r.ko, principals, resources)
+	return nil
+}
+
+// listResourceShareAssociations returns the associations of the supplied
+// type of the resource share, in every status.
+func (rm *resourceManager) listResourceShareAssociations(
+	ctx context.Context,
+	resourceType svcsdktypes.ResourceShareAssociationType,
+	resourceArn string,
+) (associations []svcsdktypes.ResourceShareAssociation, err error) {
+	input := &svcsdk.GetResourceShareAssociationsInput{
+		AssociationType:   resourceType,
+		ResourceShareArns: []string{resourceArn},
+		MaxResults:        listPageSize(),
+	}
+	for page := 1; ; page++ {
+		var resp *svcsdk.GetResourceShareAssociationsOutput
+		resp, err = rm.sdkapi.GetResourceShareAssociations(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "GetResourceShareAssociations", err)
+		if err != nil {
+			return nil, err
+		}
+		associations = append(associations, resp.ResourceShareAssociations...)
+		if resp.NextToken == nil {
+			break
+		}
+		if page >= svcconfig.ListMaxPages {
+			return nil, listLimitExceeded("GetResourceShareAssociations")
+		}
+		input.NextToken = resp.NextToken
+	}
+	return associations, nil
+}
+
+// associatedEntities returns the entities of the supplied associations that
+// are ASSOCIATED with the resource share.
+func associatedEntities(
+	associations []svcsdktypes.ResourceShareAssociation,
+) []*string {
+	entities := make([]*string, 0)
+	for _, assoc := range associations {
+		if assoc.Status == svcsdktypes.ResourceShareAssociationStatusAssociated {
+			entities = append(entities, assoc.AssociatedEntity)
+		}
+	}
+	return entities
+}
+
+// setAssociationsStatus reports the supplied principal and resource
+// associations in the status of the resource share, and sets the
+// AssociationsReady condition accordingly.
+// Associations that were already DISASSOCIATED are left out, RAM keeps
+// returning them for a while.
+func setAssociationsStatus(
+	ko *svcapitypes.ResourceShare,
+	principals []svcsdktypes.ResourceShareAssociation,
+	resources []svcsdktypes.ResourceShareAssociation,
+) {
+	ko.Status.PrincipalAssociations = associationsStatus(principals)
+	ko.Status.ResourceAssociations = associationsStatus(resources)
+	setAssociationsReady(ko)
+}
+
+// associationsStatus converts the supplied associations into their
+// representation in the status of the resource share.
+func associationsStatus(
+	associations []svcsdktypes.ResourceShareAssociation,
+) []*svcapitypes.ResourceShareAssociation_SDK {
+	res := []*svcapitypes.ResourceShareAssociation_SDK{}
+	for _, assoc := range associations {
+		if assoc.Status == svcsdktypes.ResourceShareAssociationStatusDisassociated {
+			continue
+		}
+		elem := &svcapitypes.ResourceShareAssociation_SDK{
+			AssociatedEntity:  assoc.AssociatedEntity,
+			External:          assoc.External,
+			ResourceShareARN:  assoc.ResourceShareArn,
+			ResourceShareName: assoc.ResourceShareName,
+			StatusMessage:     assoc.StatusMessage,
+		}
+		if assoc.AssociationType != "" {
+			elem.AssociationType = aws.String(string(assoc.AssociationType))
+		}
+		if assoc.CreationTime != nil {
+			elem.CreationTime = &metav1.Time{Time: *assoc.CreationTime}
+		}
+		if assoc.LastUpdatedTime != nil {
+			elem.LastUpdatedTime = &metav1.Time{Time: *assoc.LastUpdatedTime}
+		}
+		if assoc.Status != "" {
+			elem.Status = aws.String(string(assoc.Status))
+		}
+		res = append(res, elem)
 	}
-	if resp.ResourceShareAssociations != nil {
-		for _, p := range resp.ResourceShareAssociations {
-			if p.Status == svcsdktypes.ResourceShareAssociationStatusAssociated {
-				slices = append(slices, p.AssociatedEntity)
+	return res
+}
+
+// setAssociationsReady sets the AssociationsReady condition of the resource
+// share from the associations reported in its status. The condition is False
+// when an association failed, listing each failure along with its status
+// message, or when associations are still in progress. The resource share
+// isn't Synced either in that case, so that it is requeued until RAM is done
+// processing the associations.
+func setAssociationsReady(ko *svcapitypes.ResourceShare) {
+	failures := []string{}
+	inProgress := 0
+	for _, associations := range [][]*svcapitypes.ResourceShareAssociation_SDK{
+		ko.Status.PrincipalAssociations,
+		ko.Status.ResourceAssociations,
+	} {
+		for _, assoc := range associations {
+			switch svcsdktypes.ResourceShareAssociationStatus(aws.ToString(assoc.Status)) {
+			case svcsdktypes.ResourceShareAssociationStatusFailed:
+				failures = append(failures, fmt.Sprintf(
+					"%s %s failed: %s",
+					strings.ToLower(aws.ToString(assoc.AssociationType)),
+					aws.ToString(assoc.AssociatedEntity),
+					aws.ToString(assoc.StatusMessage),
+				))
+			case svcsdktypes.ResourceShareAssociationStatusAssociating,
+				svcsdktypes.ResourceShareAssociationStatusDisassociating:
+				inProgress++
 			}
 		}
 	}
-	return slices, err
+	status := corev1.ConditionTrue
+	reason := "AssociationsReady"
+	message := "All the associations of the resource share are ready"
+	switch {
+	case len(failures) > 0:
+		status = corev1.ConditionFalse
+		reason = "AssociationFailed"
+		message = strings.Join(failures, "; ")
+	case inProgress > 0:
+		status = corev1.ConditionFalse
+		reason = "AssociationInProgress"
+		message = fmt.Sprintf("%d associations are in progress", inProgress)
+	}
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == conditionTypeAssociationsReady {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{
+			Type: conditionTypeAssociationsReady,
+		}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	if cond.Status != status {
+		now := metav1.Now()
+		cond.LastTransitionTime = &now
+	}
+	cond.Status = status
+	cond.Reason = &reason
+	cond.Message = &message
+
+	if status == corev1.ConditionFalse {
+		msg := "waiting for the associations of the resource share to be ready"
+		ackcondition.SetSynced(&resource{ko}, corev1.ConditionFalse, &msg, &reason)
+	}
+}
+
+// resourceShareFailed returns a terminal error carrying the status message
+// of the resource share if RAM failed to create it.
+func resourceShareFailed(ko *svcapitypes.ResourceShare) error {
+	if ko.Status.Status == nil ||
+		*ko.Status.Status != string(svcapitypes.ResourceShareStatus_SDK_FAILED) {
+		return nil
+	}
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"resource share %s failed: %s",
+		aws.ToString(ko.Spec.Name),
+		aws.ToString(ko.Status.StatusMessage),
+	))
+}
+
+// getSourceAssociations returns the sources the service principals of the
+// supplied resource share are restricted to.
+func (rm *resourceManager) getSourceAssociations(
+	ctx context.Context,
+	resourceArn string,
+) (sources []*string, err error) {
+	input := &svcsdk.ListSourceAssociationsInput{
+		ResourceShareArns: []string{resourceArn},
+		MaxResults:        listPageSize(),
+	}
+	sources = make([]*string, 0)
+	for page := 1; ; page++ {
+		var resp *svcsdk.ListSourceAssociationsOutput
+		resp, err = rm.sdkapi.ListSourceAssociations(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListSourceAssociations", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range resp.SourceAssociations {
+			if aws.ToString(s.Status) == string(svcsdktypes.ResourceShareAssociationStatusAssociated) {
+				sources = append(sources, s.SourceId)
+			}
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		if page >= svcconfig.ListMaxPages {
+			return nil, listLimitExceeded("ListSourceAssociations")
+		}
+		input.NextToken = resp.NextToken
+	}
+	return sources, nil
 }
 
 func (rm *resourceManager) newTag(
@@ -0,0 +1,162 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"reflect"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+func TestCompareStringSlices(t *testing.T) {
+	tests := []struct {
+		name         string
+		a            []string
+		b            []string
+		wantToAdd    []string
+		wantToDelete []string
+	}{
+		{
+			name:         "equal",
+			a:            []string{"x", "y"},
+			b:            []string{"y", "x"},
+			wantToAdd:    []string{},
+			wantToDelete: []string{},
+		},
+		{
+			name:         "only additions",
+			a:            []string{"x", "y"},
+			b:            []string{"x"},
+			wantToAdd:    []string{"y"},
+			wantToDelete: []string{},
+		},
+		{
+			name:         "only deletions",
+			a:            []string{"x"},
+			b:            []string{"x", "y"},
+			wantToAdd:    []string{},
+			wantToDelete: []string{"y"},
+		},
+		{
+			name:         "additions and deletions",
+			a:            []string{"x", "z"},
+			b:            []string{"x", "y"},
+			wantToAdd:    []string{"z"},
+			wantToDelete: []string{"y"},
+		},
+		{
+			name:         "everything added",
+			a:            []string{"x", "y"},
+			wantToAdd:    []string{"x", "y"},
+			wantToDelete: []string{},
+		},
+		{
+			name:         "everything deleted",
+			b:            []string{"x", "y"},
+			wantToAdd:    []string{},
+			wantToDelete: []string{"x", "y"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toAdd, toDelete := compareStringSlices(aws.StringSlice(tt.a), aws.StringSlice(tt.b))
+			if !reflect.DeepEqual(toAdd, tt.wantToAdd) {
+				t.Errorf("compareStringSlices() toAdd = %v, want %v", toAdd, tt.wantToAdd)
+			}
+			if !reflect.DeepEqual(toDelete, tt.wantToDelete) {
+				t.Errorf("compareStringSlices() toDelete = %v, want %v", toDelete, tt.wantToDelete)
+			}
+		})
+	}
+}
+
+func TestSelectResourceShares(t *testing.T) {
+	share := func(arn string, status svcsdktypes.ResourceShareStatus) svcsdktypes.ResourceShare {
+		return svcsdktypes.ResourceShare{ResourceShareArn: aws.String(arn), Status: status}
+	}
+	const (
+		first  = "arn:aws:ram:us-west-2:111122223333:resource-share/first"
+		second = "arn:aws:ram:us-west-2:111122223333:resource-share/second"
+	)
+	tests := []struct {
+		name     string
+		arn      string
+		shares   []svcsdktypes.ResourceShare
+		wantARNs []string
+		wantErr  bool
+	}{
+		{
+			name:     "known ARN selects the matching resource share",
+			arn:      second,
+			shares:   []svcsdktypes.ResourceShare{share(first, svcsdktypes.ResourceShareStatusActive), share(second, svcsdktypes.ResourceShareStatusActive)},
+			wantARNs: []string{second},
+		},
+		{
+			name:     "known ARN without a matching resource share",
+			arn:      second,
+			shares:   []svcsdktypes.ResourceShare{share(first, svcsdktypes.ResourceShareStatusActive)},
+			wantARNs: []string{},
+		},
+		{
+			name:     "single ACTIVE resource share with the name",
+			shares:   []svcsdktypes.ResourceShare{share(first, svcsdktypes.ResourceShareStatusDeleted), share(second, svcsdktypes.ResourceShareStatusActive)},
+			wantARNs: []string{second},
+		},
+		{
+			name:    "several ACTIVE resource shares with the name",
+			shares:  []svcsdktypes.ResourceShare{share(first, svcsdktypes.ResourceShareStatusActive), share(second, svcsdktypes.ResourceShareStatusActive)},
+			wantErr: true,
+		},
+		{
+			name:     "no ACTIVE resource share with the name",
+			shares:   []svcsdktypes.ResourceShare{share(first, svcsdktypes.ResourceShareStatusPending)},
+			wantARNs: []string{first},
+		},
+		{
+			name:     "no resource share with the name",
+			shares:   []svcsdktypes.ResourceShare{},
+			wantARNs: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{
+				Spec: svcapitypes.ResourceShareSpec{Name: aws.String("share")},
+			}
+			if tt.arn != "" {
+				arn := ackv1alpha1.AWSResourceName(tt.arn)
+				ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{ARN: &arn}
+			}
+			selected, err := selectResourceShares(ko, tt.shares)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectResourceShares() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			arns := []string{}
+			for _, share := range selected {
+				arns = append(arns, aws.ToString(share.ResourceShareArn))
+			}
+			if !reflect.DeepEqual(arns, tt.wantARNs) {
+				t.Errorf("selectResourceShares() = %v, want %v", arns, tt.wantARNs)
+			}
+		})
+	}
+}
@@ -50,7 +50,7 @@ var (
 // +kubebuilder:rbac:groups=ram.services.k8s.aws,resources=resourceshares,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ram.services.k8s.aws,resources=resourceshares/status,verbs=get;update;patch
 
-var lateInitializeFieldNames = []string{}
+var lateInitializeFieldNames = []string{"AllowExternalPrincipals", "PermissionARNs"}
 
 // resourceManager is responsible for providing a consistent way to perform
 // CRUD operations in a backend AWS service API for Book custom resources.
@@ -248,6 +248,10 @@ func (rm *resourceManager) LateInitialize(
 func (rm *resourceManager) incompleteLateInitialization(
 	res acktypes.AWSResource,
 ) bool {
+	ko := rm.concreteResource(res).ko.DeepCopy()
+	if ko.Spec.AllowExternalPrincipals == nil {
+		return true
+	}
 	return false
 }
 
@@ -257,7 +261,15 @@ func (rm *resourceManager) lateInitializeFromReadOneOutput(
 	observed acktypes.AWSResource,
 	latest acktypes.AWSResource,
 ) acktypes.AWSResource {
-	return latest
+	observedKo := rm.concreteResource(observed).ko.DeepCopy()
+	latestKo := rm.concreteResource(latest).ko.DeepCopy()
+	if observedKo.Spec.AllowExternalPrincipals != nil && latestKo.Spec.AllowExternalPrincipals == nil {
+		latestKo.Spec.AllowExternalPrincipals = observedKo.Spec.AllowExternalPrincipals
+	}
+	if observedKo.Spec.PermissionARNs != nil && latestKo.Spec.PermissionARNs == nil {
+		latestKo.Spec.PermissionARNs = observedKo.Spec.PermissionARNs
+	}
+	return &resource{latestKo}
 }
 
 // IsSynced returns true if the resource is synced.
@@ -268,6 +280,14 @@ func (rm *resourceManager) IsSynced(ctx context.Context, res acktypes.AWSResourc
 		panic("resource manager's IsSynced() method received resource with nil CR object")
 	}
 
+	if r.ko.Status.Status == nil {
+		return false, nil
+	}
+	statusCandidates := []string{"ACTIVE"}
+	if !ackutil.InStrings(*r.ko.Status.Status, statusCandidates) {
+		return false, nil
+	}
+
 	return true, nil
 }
 
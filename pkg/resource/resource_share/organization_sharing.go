@@ -0,0 +1,132 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	organizationsharing "github.com/aws-controllers-k8s/ram-controller/pkg/organization_sharing"
+)
+
+// conditionTypeOrganizationSharingEnabled is the type of the condition
+// reporting whether sharing with AWS Organizations is enabled, which a
+// resource share needs to be shared with an organization or an
+// organizational unit.
+const conditionTypeOrganizationSharingEnabled ackv1alpha1.ConditionType = "OrganizationSharingEnabled"
+
+// organizationSharingRefreshNote is appended to the message of the
+// OrganizationSharingEnabled condition. The resource share isn't reconciled
+// when the status of the OrganizationSharing resource changes, only when its
+// own spec changes, when an update fails, or after the resync period.
+const organizationSharingRefreshNote = "This condition is refreshed the next time the resource share is " +
+	"reconciled, at the latest after the resync period of the controller"
+
+// hasOrganizationPrincipals returns whether the desired resource share is
+// shared with an organization or an organizational unit.
+func hasOrganizationPrincipals(ko *svcapitypes.ResourceShare) bool {
+	for _, p := range ko.Spec.Principals {
+		parsed, err := arn.Parse(aws.ToString(p))
+		if err != nil || parsed.Service != "organizations" {
+			continue
+		}
+		if strings.HasPrefix(parsed.Resource, "organization/") ||
+			strings.HasPrefix(parsed.Resource, "ou/") {
+			return true
+		}
+	}
+	return false
+}
+
+// setOrganizationSharingCondition sets the OrganizationSharingEnabled
+// condition of a resource share whose desired principals include an
+// organization or an organizational unit from the state reported by the
+// OrganizationSharing resource, and removes it from the other resource
+// shares. It is called while resolving the references of the desired
+// resource share, before any call to RAM, so that the condition is reported
+// even when RAM refuses to associate the principals.
+func setOrganizationSharingCondition(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) error {
+	if !hasOrganizationPrincipals(ko) {
+		conditions := []*ackv1alpha1.Condition{}
+		for _, c := range ko.Status.Conditions {
+			if c.Type != conditionTypeOrganizationSharingEnabled {
+				conditions = append(conditions, c)
+			}
+		}
+		ko.Status.Conditions = conditions
+		return nil
+	}
+
+	state, err := organizationsharing.CurrentState(ctx, apiReader)
+	if err != nil {
+		return err
+	}
+	status := corev1.ConditionTrue
+	reason := "OrganizationSharingEnabled"
+	message := fmt.Sprintf("Sharing with AWS Organizations is enabled by OrganizationSharing %q", state.Name)
+	switch {
+	case !state.Known:
+		status = corev1.ConditionUnknown
+		reason = "OrganizationSharingUnknown"
+		message = "No OrganizationSharing resource reports whether sharing with AWS Organizations " +
+			"is enabled, create one named default to enable it"
+	case !state.Enabled:
+		status = corev1.ConditionFalse
+		reason = "OrganizationSharingDisabled"
+		message = fmt.Sprintf(
+			"Sharing with AWS Organizations is disabled, see the status of OrganizationSharing %q",
+			state.Name,
+		)
+		if state.Message != "" {
+			message += ": " + state.Message
+		}
+	}
+
+	message += ". " + organizationSharingRefreshNote
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == conditionTypeOrganizationSharingEnabled {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{
+			Type: conditionTypeOrganizationSharingEnabled,
+		}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	if cond.Status != status {
+		now := metav1.Now()
+		cond.LastTransitionTime = &now
+	}
+	cond.Status = status
+	cond.Reason = &reason
+	cond.Message = &message
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+)
+
+const (
+	// promotionRequeueAfter is how long the controller waits before reading
+	// a resource share being promoted to STANDARD again.
+	promotionRequeueAfter = 15 * time.Second
+	// promotedPermissionNameSuffix is appended to the name of a
+	// CREATED_FROM_POLICY permission to name the customer managed permission
+	// it is promoted to.
+	promotedPermissionNameSuffix = "-promoted"
+	// promotedPermissionNameHashLength is the number of hexadecimal digits
+	// of the hash of the original name kept in a truncated promoted name.
+	promotedPermissionNameHashLength = 8
+	// maxPermissionNameLength is the length of the longest permission name
+	// RAM accepts.
+	maxPermissionNameLength = 36
+)
+
+// comparePromotion reports a difference at Spec.PromoteCreatedFromPolicy
+// when the spec opts in and the resource share is still CREATED_FROM_POLICY,
+// so that sdkUpdate promotes it.
+func comparePromotion(
+	delta *ackcompare.Delta,
+	a *resource,
+	b *resource,
+) {
+	if promotionNeeded(a.ko, b.ko) {
+		delta.Add("Spec.PromoteCreatedFromPolicy", a.ko.Spec.PromoteCreatedFromPolicy, b.ko.Spec.PromoteCreatedFromPolicy)
+	}
+}
+
+// promotionNeeded returns whether the desired resource share opts in to be
+// promoted and the latest one is still CREATED_FROM_POLICY.
+func promotionNeeded(desired, latest *svcapitypes.ResourceShare) bool {
+	return aws.ToBool(desired.Spec.PromoteCreatedFromPolicy) &&
+		aws.ToString(latest.Status.FeatureSet) == string(svcsdktypes.ResourceShareFeatureSetCreatedFromPolicy)
+}
+
+// promotionInProgress returns a requeue error while RAM is promoting the
+// resource share to STANDARD.
+func promotionInProgress(ko *svcapitypes.ResourceShare) error {
+	if aws.ToString(ko.Status.FeatureSet) != string(svcsdktypes.ResourceShareFeatureSetPromotingToStandard) {
+		return nil
+	}
+	return ackrequeue.NeededAfter(
+		fmt.Errorf(
+			"resource share is being promoted from %s to %s",
+			svcsdktypes.ResourceShareFeatureSetCreatedFromPolicy,
+			svcsdktypes.ResourceShareFeatureSetStandard,
+		),
+		promotionRequeueAfter,
+	)
+}
+
+// promoteCreatedFromPolicy promotes a CREATED_FROM_POLICY resource share to
+// a STANDARD one RAM lets the controller manage, when its spec opts in with
+// PromoteCreatedFromPolicy. Its CREATED_FROM_POLICY permissions are promoted
+// to customer managed permissions first, RAM associates them with the
+// resource share once it is promoted. It returns the desired resource along
+// with a requeue error, the other updates waiting until RAM is done
+// promoting the resource share.
+func (rm *resourceManager) promoteCreatedFromPolicy(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (updated *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.promoteCreatedFromPolicy")
+	defer func() {
+		exit(err)
+	}()
+	resourceArn := resourceShareARN(latest.ko)
+	ko := desired.ko.DeepCopy()
+	if err = rm.promotePermissionsCreatedFromPolicy(ctx, &resource{ko}, resourceArn); err != nil {
+		return &resource{ko}, err
+	}
+	rlog.Debug("promoting ResourceShare resource to STANDARD", "resourceShareArn", resourceArn)
+	_, err = rm.sdkapi.PromoteResourceShareCreatedFromPolicy(
+		ctx,
+		&svcsdk.PromoteResourceShareCreatedFromPolicyInput{
+			ResourceShareArn: aws.String(resourceArn),
+		},
+	)
+	rm.metrics.RecordAPICall("UPDATE", "PromoteResourceShareCreatedFromPolicy", err)
+	if err != nil {
+		return &resource{ko}, err
+	}
+	bumpClientTokenNonce(ko)
+	ko.Status.FeatureSet = aws.String(string(svcsdktypes.ResourceShareFeatureSetPromotingToStandard))
+	rm.setStatusDefaults(ko)
+	return &resource{ko}, promotionInProgress(ko)
+}
+
+// promotePermissionsCreatedFromPolicy promotes the CREATED_FROM_POLICY
+// permissions of the resource share to customer managed permissions, and
+// records the ARNs of the promoted permissions in
+// Status.PromotedPermissionARNs. A permission already promoted by an
+// earlier reconciliation is skipped. A customer managed permission that
+// already has the promoted name without having been promoted for this
+// resource share results in a terminal error.
+func (rm *resourceManager) promotePermissionsCreatedFromPolicy(
+	ctx context.Context,
+	r *resource,
+	resourceArn string,
+) error {
+	rlog := ackrtlog.FromContext(ctx)
+	input := &svcsdk.ListResourceSharePermissionsInput{
+		ResourceShareArn: aws.String(resourceArn),
+		MaxResults:       listPageSize(),
+	}
+	for page := 1; ; page++ {
+		resp, err := rm.sdkapi.ListResourceSharePermissions(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "ListResourceSharePermissions", err)
+		if err != nil {
+			return err
+		}
+		for _, p := range resp.Permissions {
+			if p.FeatureSet != svcsdktypes.PermissionFeatureSetCreatedFromPolicy {
+				continue
+			}
+			permissionArn := aws.ToString(p.Arn)
+			name := promotedPermissionName(aws.ToString(p.Name))
+			if promotedPermission(r.ko, name) {
+				continue
+			}
+			rlog.Debug("promoting permission of ResourceShare resource", "permissionArn", permissionArn, "name", name)
+			var promoted *svcsdk.PromotePermissionCreatedFromPolicyOutput
+			promoted, err = rm.sdkapi.PromotePermissionCreatedFromPolicy(
+				ctx,
+				&svcsdk.PromotePermissionCreatedFromPolicyInput{
+					PermissionArn: p.Arn,
+					Name:          aws.String(name),
+					ClientToken:   clientToken(r, "PromotePermissionCreatedFromPolicy", []string{permissionArn}),
+				},
+			)
+			rm.metrics.RecordAPICall("CREATE", "PromotePermissionCreatedFromPolicy", err)
+			var alreadyExists *svcsdktypes.PermissionAlreadyExistsException
+			if errors.As(err, &alreadyExists) {
+				return ackerr.NewTerminalError(fmt.Errorf(
+					"can't promote permission %s: a customer managed permission named %s already exists",
+					permissionArn, name,
+				))
+			}
+			if err != nil {
+				return err
+			}
+			if promoted.Permission != nil && promoted.Permission.Arn != nil {
+				r.ko.Status.PromotedPermissionARNs = append(
+					r.ko.Status.PromotedPermissionARNs, promoted.Permission.Arn,
+				)
+			}
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		if page >= svcconfig.ListMaxPages {
+			return listLimitExceeded("ListResourceSharePermissions")
+		}
+		input.NextToken = resp.NextToken
+	}
+	return nil
+}
+
+// promotedPermission returns whether the customer managed permission with
+// the supplied name was promoted for the resource share, as recorded in
+// Status.PromotedPermissionARNs.
+func promotedPermission(ko *svcapitypes.ResourceShare, name string) bool {
+	for _, arn := range ko.Status.PromotedPermissionARNs {
+		if strings.HasSuffix(aws.ToString(arn), ":permission/"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// promotedPermissionName returns the name of the customer managed
+// permission the CREATED_FROM_POLICY permission with the supplied name is
+// promoted to. A name too long to take the suffix is truncated and keeps a
+// hash of the whole name, so that two long names sharing a prefix aren't
+// promoted to the same name.
+func promotedPermissionName(name string) string {
+	if len(name)+len(promotedPermissionNameSuffix) <= maxPermissionNameLength {
+		return name + promotedPermissionNameSuffix
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:promotedPermissionNameHashLength]
+	max := maxPermissionNameLength - len(promotedPermissionNameSuffix) - len(hash) - 1
+	return name[:max] + "-" + hash + promotedPermissionNameSuffix
+}
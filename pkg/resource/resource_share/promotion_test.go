@@ -0,0 +1,84 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+// TestPromotionDeltas checks that the changes requested along with the
+// promotion of a resource share, which sdkUpdate doesn't make while
+// promoting it, are still reported once it is STANDARD, so that the next
+// reconciliation makes them.
+func TestPromotionDeltas(t *testing.T) {
+	desired := &resource{&svcapitypes.ResourceShare{
+		Spec: svcapitypes.ResourceShareSpec{
+			AllowExternalPrincipals:  aws.Bool(false),
+			Name:                     aws.String("promoted"),
+			PromoteCreatedFromPolicy: aws.Bool(true),
+			Tags:                     []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}},
+		},
+	}}
+	tests := []struct {
+		name          string
+		featureSet    svcsdktypes.ResourceShareFeatureSet
+		wantPromotion bool
+		wantRequeue   bool
+	}{
+		{
+			name:          "created from policy",
+			featureSet:    svcsdktypes.ResourceShareFeatureSetCreatedFromPolicy,
+			wantPromotion: true,
+		},
+		{
+			name:        "promoting to standard",
+			featureSet:  svcsdktypes.ResourceShareFeatureSetPromotingToStandard,
+			wantRequeue: true,
+		},
+		{
+			name:       "standard",
+			featureSet: svcsdktypes.ResourceShareFeatureSetStandard,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest := &resource{&svcapitypes.ResourceShare{
+				Spec: svcapitypes.ResourceShareSpec{
+					AllowExternalPrincipals: aws.Bool(true),
+					Name:                    aws.String("created-from-policy"),
+				},
+				Status: svcapitypes.ResourceShareStatus{
+					FeatureSet: aws.String(string(tt.featureSet)),
+				},
+			}}
+			delta := newResourceDelta(desired, latest)
+			if got := delta.DifferentAt("Spec.PromoteCreatedFromPolicy"); got != tt.wantPromotion {
+				t.Errorf("DifferentAt(Spec.PromoteCreatedFromPolicy) = %v, want %v", got, tt.wantPromotion)
+			}
+			for _, path := range []string{"Spec.AllowExternalPrincipals", "Spec.Name", "Spec.Tags"} {
+				if !delta.DifferentAt(path) {
+					t.Errorf("DifferentAt(%s) = false, want true", path)
+				}
+			}
+			if got := promotionInProgress(latest.ko) != nil; got != tt.wantRequeue {
+				t.Errorf("promotionInProgress() != nil = %v, want %v", got, tt.wantRequeue)
+			}
+		})
+	}
+}
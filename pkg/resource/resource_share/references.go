@@ -41,6 +41,14 @@ func (rm *resourceManager) ClearResolvedReferences(res acktypes.AWSResource) ack
 		ko.Spec.PermissionARNs = nil
 	}
 
+	if len(ko.Spec.PrincipalRefs) > 0 || len(ko.Spec.PrincipalSetRefs) > 0 {
+		ko.Spec.Principals = nil
+	}
+
+	if len(ko.Spec.ResourceRefs) > 0 || len(ko.Spec.ResourceSelectors) > 0 {
+		ko.Spec.ResourceARNs = nil
+	}
+
 	return &resource{ko}
 }
 
@@ -66,6 +74,37 @@ func (rm *resourceManager) ResolveReferences(
 		resourceHasReferences = resourceHasReferences || fieldHasReferences
 	}
 
+	if fieldHasReferences, err := rm.resolveReferenceForPrincipals(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForPrincipalSets(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveReferenceForResourceARNs(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if fieldHasReferences, err := rm.resolveResourceSelectors(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, (resourceHasReferences || fieldHasReferences), err
+	} else {
+		resourceHasReferences = resourceHasReferences || fieldHasReferences
+	}
+
+	if err := setOrganizationSharingCondition(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, resourceHasReferences, err
+	}
+	if err := setEntitiesFromAssociations(ctx, apiReader, ko); err != nil {
+		return &resource{ko}, resourceHasReferences, err
+	}
+
 	return &resource{ko}, resourceHasReferences, err
 }
 
@@ -76,6 +115,22 @@ func validateReferenceFields(ko *svcapitypes.ResourceShare) error {
 	if len(ko.Spec.PermissionRefs) > 0 && len(ko.Spec.PermissionARNs) > 0 {
 		return ackerr.ResourceReferenceAndIDNotSupportedFor("PermissionARNs", "PermissionRefs")
 	}
+
+	if len(ko.Spec.PrincipalRefs) > 0 && len(ko.Spec.Principals) > 0 {
+		return ackerr.ResourceReferenceAndIDNotSupportedFor("Principals", "PrincipalRefs")
+	}
+
+	if len(ko.Spec.PrincipalSetRefs) > 0 && len(ko.Spec.Principals) > 0 {
+		return ackerr.ResourceReferenceAndIDNotSupportedFor("Principals", "PrincipalSetRefs")
+	}
+
+	if len(ko.Spec.ResourceRefs) > 0 && len(ko.Spec.ResourceARNs) > 0 {
+		return ackerr.ResourceReferenceAndIDNotSupportedFor("ResourceARNs", "ResourceRefs")
+	}
+
+	if len(ko.Spec.ResourceSelectors) > 0 && len(ko.Spec.ResourceARNs) > 0 {
+		return ackerr.ResourceReferenceAndIDNotSupportedFor("ResourceARNs", "ResourceSelectors")
+	}
 	return nil
 }
 
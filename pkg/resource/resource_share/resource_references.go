@@ -0,0 +1,287 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	resourceselector "github.com/aws-controllers-k8s/ram-controller/pkg/resource_selector"
+)
+
+// The controller can read and watch the resources of the ACK services whose
+// resources are commonly shared with RAM. Referencing or selecting the
+// resources of other services requires granting the controller read access
+// to their API group, as documented on the Group fields of the references
+// and selectors. Until then, resolving them fails with an error saying so.
+// +kubebuilder:rbac:groups=ec2.services.k8s.aws;networkfirewall.services.k8s.aws;organizations.services.k8s.aws;rds.services.k8s.aws;route53resolver.services.k8s.aws,resources=*,verbs=get;list;watch
+
+const (
+	// ackAPIGroupSuffix is the suffix of the API groups of the ACK services.
+	ackAPIGroupSuffix = ".services.k8s.aws"
+	// defaultReferenceVersion is the API version of a referenced resource
+	// when the reference doesn't specify one.
+	defaultReferenceVersion = "v1alpha1"
+)
+
+// referencedResourceStatus is the part of the status shared by every ACK
+// resource that is needed to resolve a reference to it.
+type referencedResourceStatus struct {
+	Status struct {
+		ACKResourceMetadata *ackv1alpha1.ResourceMetadata `json:"ackResourceMetadata"`
+		Conditions          []*ackv1alpha1.Condition      `json:"conditions"`
+	} `json:"status"`
+}
+
+// resolveReferenceForResourceARNs reads the ACK resources referenced from
+// the ResourceRefs field and sets the ResourceARNs from their ARNs. Returns
+// a boolean indicating whether a reference contains references, or an error
+func (rm *resourceManager) resolveReferenceForResourceARNs(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) (hasReferences bool, err error) {
+	for _, f0iter := range ko.Spec.ResourceRefs {
+		if f0iter != nil && f0iter.From != nil {
+			hasReferences = true
+			arn, err := resolveACKResourceARN(ctx, apiReader, ko, f0iter.From, "ResourceRefs")
+			if err != nil {
+				return hasReferences, err
+			}
+			if ko.Spec.ResourceARNs == nil {
+				ko.Spec.ResourceARNs = make([]*string, 0, 1)
+			}
+			if !ackutil.InStrings(*arn, aws.ToStringSlice(ko.Spec.ResourceARNs)) {
+				ko.Spec.ResourceARNs = append(ko.Spec.ResourceARNs, arn)
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// resolveReferenceForPrincipals reads the ACK resources referenced from the
+// PrincipalRefs field, typically Organizations accounts and organizational
+// units, and sets the Principals from their ARNs. Returns a boolean
+// indicating whether a reference contains references, or an error
+func (rm *resourceManager) resolveReferenceForPrincipals(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) (hasReferences bool, err error) {
+	for _, f0iter := range ko.Spec.PrincipalRefs {
+		if f0iter != nil && f0iter.From != nil {
+			hasReferences = true
+			arn, err := resolveACKResourceARN(ctx, apiReader, ko, f0iter.From, "PrincipalRefs")
+			if err != nil {
+				return hasReferences, err
+			}
+			if ko.Spec.Principals == nil {
+				ko.Spec.Principals = make([]*string, 0, 1)
+			}
+			principal := principalFromARN(*arn)
+			if !ackutil.InStrings(principal, aws.ToStringSlice(ko.Spec.Principals)) {
+				ko.Spec.Principals = append(ko.Spec.Principals, aws.String(principal))
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// resolveReferenceForPrincipalSets reads the PrincipalSet resources
+// referenced from the PrincipalSetRefs field and adds their principals to
+// the Principals. Returns a boolean indicating whether a reference contains
+// references, or an error
+func (rm *resourceManager) resolveReferenceForPrincipalSets(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) (hasReferences bool, err error) {
+	for _, f0iter := range ko.Spec.PrincipalSetRefs {
+		if f0iter != nil && f0iter.From != nil {
+			hasReferences = true
+			if f0iter.From.Name == nil || *f0iter.From.Name == "" {
+				return hasReferences, fmt.Errorf("provided resource reference is nil or empty: PrincipalSetRefs")
+			}
+			obj := &svcapitypes.PrincipalSet{}
+			if err := apiReader.Get(ctx, resourceselector.PrincipalSetKey(ko, f0iter), obj); err != nil {
+				return hasReferences, err
+			}
+			if ko.Spec.Principals == nil {
+				ko.Spec.Principals = make([]*string, 0, len(obj.Spec.Principals))
+			}
+			for _, principal := range obj.Spec.Principals {
+				if principal != nil &&
+					!ackutil.InStrings(*principal, aws.ToStringSlice(ko.Spec.Principals)) {
+					ko.Spec.Principals = append(ko.Spec.Principals, principal)
+				}
+			}
+		}
+	}
+
+	return hasReferences, nil
+}
+
+// principalFromARN returns the principal RAM expects for the supplied ARN:
+// the ID of an Organizations account, which RAM doesn't accept as an ARN,
+// and the ARN itself for anything else, such as an organization or an
+// organizational unit.
+func principalFromARN(s string) string {
+	parsed, err := arn.Parse(s)
+	if err != nil || parsed.Service != "organizations" ||
+		!strings.HasPrefix(parsed.Resource, "account/") {
+		return s
+	}
+	return parsed.Resource[strings.LastIndex(parsed.Resource, "/")+1:]
+}
+
+// resolveACKResourceARN returns the ARN of the ACK resource the supplied
+// reference of the supplied field points to.
+func resolveACKResourceARN(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.ResourceShare,
+	arr *svcapitypes.ResourceReference,
+	field string,
+) (*string, error) {
+	if arr.Name == nil || *arr.Name == "" ||
+		arr.Kind == nil || *arr.Kind == "" ||
+		arr.Group == nil || *arr.Group == "" {
+		return nil, fmt.Errorf("provided resource reference is nil or empty: %s", field)
+	}
+	if !strings.HasSuffix(*arr.Group, ackAPIGroupSuffix) {
+		return nil, fmt.Errorf(
+			"provided resource reference isn't an ACK resource: %s: group %s doesn't end with %s",
+			field, *arr.Group, ackAPIGroupSuffix,
+		)
+	}
+	namespace := ko.ObjectMeta.GetNamespace()
+	if arr.Namespace != nil && *arr.Namespace != "" {
+		namespace = *arr.Namespace
+	}
+	version := defaultReferenceVersion
+	if arr.Version != nil && *arr.Version != "" {
+		version = *arr.Version
+	}
+	gvk := schema.GroupVersionKind{
+		Group:   *arr.Group,
+		Version: version,
+		Kind:    *arr.Kind,
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	arn, err := getReferencedResourceState_ACKResource(ctx, apiReader, obj, *arr.Name, namespace)
+	if err != nil {
+		return nil, resourceselector.ForbiddenError(field, gvk, err)
+	}
+	return arn, nil
+}
+
+// getReferencedResourceState_ACKResource looks up whether a referenced ACK
+// resource of any kind exists and is in a ACK.ResourceSynced=True state. If
+// the referenced resource does exist and is in a Synced state, returns its
+// ARN, otherwise returns `ackerr.ResourceReferenceTerminalFor` or
+// `ResourceReferenceNotSyncedFor` depending on if the resource is in a
+// Terminal state.
+func getReferencedResourceState_ACKResource(
+	ctx context.Context,
+	apiReader client.Reader,
+	obj *unstructured.Unstructured,
+	name string, // the Kubernetes name of the referenced resource
+	namespace string, // the Kubernetes namespace of the referenced resource
+) (*string, error) {
+	kind := obj.GetKind()
+	namespacedName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := apiReader.Get(ctx, namespacedName, obj)
+	if err != nil {
+		return nil, err
+	}
+	var state referencedResourceStatus
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &state); err != nil {
+		return nil, err
+	}
+	for _, cond := range state.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeTerminal &&
+			cond.Status == corev1.ConditionTrue {
+			return nil, ackerr.ResourceReferenceTerminalFor(
+				kind,
+				namespace, name)
+		}
+	}
+	var refResourceSynced bool
+	for _, cond := range state.Status.Conditions {
+		if cond.Type == ackv1alpha1.ConditionTypeResourceSynced &&
+			cond.Status == corev1.ConditionTrue {
+			refResourceSynced = true
+		}
+	}
+	if !refResourceSynced {
+		return nil, ackerr.ResourceReferenceNotSyncedFor(
+			kind,
+			namespace, name)
+	}
+	if state.Status.ACKResourceMetadata == nil || state.Status.ACKResourceMetadata.ARN == nil {
+		return nil, ackerr.ResourceReferenceMissingTargetFieldFor(
+			kind,
+			namespace, name,
+			"Status.ACKResourceMetadata.ARN")
+	}
+	return (*string)(state.Status.ACKResourceMetadata.ARN), nil
+}
+
+// resolveResourceSelectors adds the ARNs of the ACK resources selected by
+// the ResourceSelectors field to the ResourceARNs. The ResourceARNs are set
+// even when no resource is selected, so that the resources that stopped
+// matching the selectors are disassociated. Returns a boolean indicating
+// whether the resource has selectors, or an error
+func (rm *resourceManager) resolveResourceSelectors(
+	ctx context.Context,
+	apiReader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) (hasReferences bool, err error) {
+	if len(ko.Spec.ResourceSelectors) == 0 {
+		return false, nil
+	}
+	arns, err := resourceselector.SelectedARNs(ctx, apiReader, ko)
+	if err != nil {
+		return true, err
+	}
+	if ko.Spec.ResourceARNs == nil {
+		ko.Spec.ResourceARNs = make([]*string, 0, len(arns))
+	}
+	for _, arn := range arns {
+		if !ackutil.InStrings(*arn, aws.ToStringSlice(ko.Spec.ResourceARNs)) {
+			ko.Spec.ResourceARNs = append(ko.Spec.ResourceARNs, arn)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,59 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share
+
+import (
+	"testing"
+)
+
+func TestPrincipalFromARN(t *testing.T) {
+	tests := []struct {
+		name string
+		arn  string
+		want string
+	}{
+		{
+			name: "Organizations account",
+			arn:  "arn:aws:organizations::111122223333:account/o-exampleorgid/444455556666",
+			want: "444455556666",
+		},
+		{
+			name: "organization",
+			arn:  "arn:aws:organizations::111122223333:organization/o-exampleorgid",
+			want: "arn:aws:organizations::111122223333:organization/o-exampleorgid",
+		},
+		{
+			name: "organizational unit",
+			arn:  "arn:aws:organizations::111122223333:ou/o-exampleorgid/ou-examplerootid-exampleouid",
+			want: "arn:aws:organizations::111122223333:ou/o-exampleorgid/ou-examplerootid-exampleouid",
+		},
+		{
+			name: "IAM role",
+			arn:  "arn:aws:iam::111122223333:role/example",
+			want: "arn:aws:iam::111122223333:role/example",
+		},
+		{
+			name: "account ID",
+			arn:  "444455556666",
+			want: "444455556666",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := principalFromARN(tt.arn); got != tt.want {
+				t.Errorf("principalFromARN(%s) = %s, want %s", tt.arn, got, tt.want)
+			}
+		})
+	}
+}
@@ -79,6 +79,11 @@ func (rm *resourceManager) sdkFind(
 	} else {
 		input.ResourceOwner = svcsdktypes.ResourceOwnerOtherAccounts
 	}
+	input.MaxResults = listPageSize()
+	if arn := resourceShareARN(r.ko); arn != "" {
+		input.Name = nil
+		input.ResourceShareArns = []string{arn}
+	}
 	var resp *svcsdk.GetResourceSharesOutput
 	resp, err = rm.sdkapi.GetResourceShares(ctx, input)
 	rm.metrics.RecordAPICall("READ_MANY", "GetResourceShares", err)
@@ -89,6 +94,12 @@ func (rm *resourceManager) sdkFind(
 		}
 		return nil, err
 	}
+	if err = rm.getRemainingResourceShares(ctx, input, resp); err != nil {
+		return nil, err
+	}
+	if resp.ResourceShares, err = selectResourceShares(r.ko, resp.ResourceShares); err != nil {
+		return nil, err
+	}
 
 	// Merge in the information we read from the API call above to the copy of
 	// the original Kubernetes object we passed to the function
@@ -174,6 +185,12 @@ func (rm *resourceManager) sdkFind(
 	if err = rm.getResourceShareAssociations(ctx, &resource{ko}); err != nil {
 		return nil, err
 	}
+	if err = resourceShareFailed(ko); err != nil && !r.IsBeingDeleted() {
+		return &resource{ko}, err
+	}
+	if err = promotionInProgress(ko); err != nil && !r.IsBeingDeleted() {
+		return &resource{ko}, err
+	}
 
 	return &resource{ko}, nil
 }
@@ -214,11 +231,18 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	if err = validateAssociationMode(desired); err != nil {
+		return nil, err
+	}
+	if err = rm.setDefaultPermissions(ctx, desired); err != nil {
+		return nil, err
+	}
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
 
+	input.ClientToken = clientToken(desired, "CreateResourceShare")
 	var resp *svcsdk.CreateResourceShareOutput
 	_ = resp
 	resp, err = rm.sdkapi.CreateResourceShare(ctx, input)
@@ -294,6 +318,9 @@ func (rm *resourceManager) sdkCreate(
 		ko.Spec.Tags = nil
 	}
 
+	setManagedAssociations(ko)
+	bumpClientTokenNonce(ko)
+
 	rm.setStatusDefaults(ko)
 	return &resource{ko}, nil
 }
@@ -355,6 +382,15 @@ func (rm *resourceManager) sdkUpdate(
 	defer func() {
 		exit(err)
 	}()
+	if err := validateAssociationMode(desired); err != nil {
+		return nil, err
+	}
+	// RAM only updates STANDARD resource shares, the other changes are still
+	// different once the promotion is done and made by a later reconcile.
+	if delta.DifferentAt("Spec.PromoteCreatedFromPolicy") {
+		return rm.promoteCreatedFromPolicy(ctx, desired, latest)
+	}
+
 	if delta.DifferentAt("Spec.Tags") {
 		if err := rm.syncTags(ctx, desired, latest); err != nil {
 			return nil, err
@@ -367,13 +403,40 @@ func (rm *resourceManager) sdkUpdate(
 		}
 	}
 
+	// Resolved after syncing the permissions, RAM attaches the default
+	// permissions along with the resources of the new types.
+	if delta.DifferentAt("Spec.ResourceARNs") {
+		if err := rm.addDefaultPermissions(ctx, desired, latest); err != nil {
+			return nil, err
+		}
+	}
+
 	if delta.DifferentAt("Spec.ResourceARNs") || delta.DifferentAt("Spec.Principals") || delta.DifferentAt("Spec.Sources") {
 		if err := rm.syncResourceShareResources(ctx, desired, latest); err != nil {
-			return nil, err
+			// desired carries the OrganizationSharingEnabled condition,
+			// which explains why RAM refused an organization principal.
+			return desired, err
 		}
 	}
 
-	if !delta.DifferentExcept("Spec.Tags", "Spec.PermissionARNs", "Spec.ResourceARNs", "Spec.Principals", "Spec.Sources") {
+	if err := rm.refreshAssociationsStatus(ctx, desired, resourceShareARN(latest.ko)); err != nil {
+		return nil, err
+	}
+	bumpClientTokenNonce(desired.ko)
+
+	if !delta.DifferentExcept(
+		"Spec.Tags",
+		"Spec.PermissionARNs",
+		"Spec.ResourceARNs",
+		"Spec.Principals",
+		"Spec.Sources",
+		"Spec.AssociationMode",
+		"Spec.ResourceRefs",
+		"Spec.ResourceSelectors",
+		"Spec.PrincipalRefs",
+		"Spec.PrincipalSetRefs",
+		"Spec.PromoteCreatedFromPolicy",
+	) {
 		return desired, nil
 	}
 
@@ -0,0 +1,206 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share_association
+
+import (
+	"context"
+	"fmt"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+	"github.com/aws-controllers-k8s/ram-controller/pkg/idempotency"
+)
+
+// listPageSize returns the MaxResults of the RAM list calls.
+func listPageSize() *int32 {
+	return aws.Int32(svcconfig.ListPageSize)
+}
+
+// listLimitExceeded returns the terminal error reported when the supplied
+// RAM list operation has more pages than the controller is allowed to read.
+func listLimitExceeded(operation string) error {
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"%s returned more than %d pages of %d items, increase --%s to manage this association",
+		operation, svcconfig.ListMaxPages, svcconfig.ListPageSize,
+		svcconfig.FlagListMaxPages,
+	))
+}
+
+// getRemainingResourceShareAssociations follows the NextToken of the
+// supplied GetResourceShareAssociations response and appends the
+// associations of the following pages to it.
+func (rm *resourceManager) getRemainingResourceShareAssociations(
+	ctx context.Context,
+	input *svcsdk.GetResourceShareAssociationsInput,
+	resp *svcsdk.GetResourceShareAssociationsOutput,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.getRemainingResourceShareAssociations")
+	defer func() {
+		exit(err)
+	}()
+	for page := 1; resp.NextToken != nil; page++ {
+		if page >= svcconfig.ListMaxPages {
+			return listLimitExceeded("GetResourceShareAssociations")
+		}
+		input.NextToken = resp.NextToken
+		var next *svcsdk.GetResourceShareAssociationsOutput
+		next, err = rm.sdkapi.GetResourceShareAssociations(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "GetResourceShareAssociations", err)
+		if err != nil {
+			return err
+		}
+		resp.ResourceShareAssociations = append(resp.ResourceShareAssociations, next.ResourceShareAssociations...)
+		resp.NextToken = next.NextToken
+	}
+	return nil
+}
+
+// validateAssociatedEntity returns a terminal error unless exactly one of
+// Spec.Principal and Spec.ResourceARN is set.
+func validateAssociatedEntity(r *resource) error {
+	if (r.ko.Spec.Principal == nil) == (r.ko.Spec.ResourceARN == nil) {
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"exactly one of principal and resourceARN must be specified",
+		))
+	}
+	return nil
+}
+
+// associatedEntity returns the principal or the resource ARN the resource
+// adds to the resource share.
+func associatedEntity(ko *svcapitypes.ResourceShareAssociation) string {
+	if ko.Spec.Principal != nil {
+		return *ko.Spec.Principal
+	}
+	return aws.ToString(ko.Spec.ResourceARN)
+}
+
+// associationType returns the type of association the resource manages.
+func associationType(
+	ko *svcapitypes.ResourceShareAssociation,
+) svcsdktypes.ResourceShareAssociationType {
+	if ko.Spec.Principal != nil {
+		return svcsdktypes.ResourceShareAssociationTypePrincipal
+	}
+	return svcsdktypes.ResourceShareAssociationTypeResource
+}
+
+// clientToken returns the client token of the supplied call made to
+// associate the entity of the resource with the resource share, or to
+// disassociate it.
+func clientToken(ko *svcapitypes.ResourceShareAssociation, operation string) *string {
+	return idempotency.ClientToken(
+		ko, ko.Status.ClientTokenNonce, operation,
+		[]string{aws.ToString(ko.Spec.ResourceShareARN)},
+		[]string{associatedEntity(ko)},
+	)
+}
+
+// bumpClientTokenNonce bumps the nonce of the client tokens of the resource
+// once the calls made with the current one succeeded.
+func bumpClientTokenNonce(ko *svcapitypes.ResourceShareAssociation) {
+	ko.Status.ClientTokenNonce = idempotency.NextNonce(ko.Status.ClientTokenNonce)
+}
+
+// associatedEntities returns the Principals and ResourceArns parameters of
+// the AssociateResourceShare and DisassociateResourceShare calls for the
+// resource.
+func associatedEntities(
+	ko *svcapitypes.ResourceShareAssociation,
+) (principals []string, resourceARNs []string) {
+	if ko.Spec.Principal != nil {
+		return []string{*ko.Spec.Principal}, nil
+	}
+	if ko.Spec.ResourceARN != nil {
+		return nil, []string{*ko.Spec.ResourceARN}
+	}
+	return nil, nil
+}
+
+// filterAssociations drops the associations that aren't for the entity of
+// the resource, as well as the ones that were already disassociated. RAM
+// keeps returning disassociated entities for a while, and they must not
+// prevent the entity from being associated again.
+func filterAssociations(
+	r *resource,
+	associations []svcsdktypes.ResourceShareAssociation,
+) []svcsdktypes.ResourceShareAssociation {
+	entity := associatedEntity(r.ko)
+	filtered := []svcsdktypes.ResourceShareAssociation{}
+	for _, assoc := range associations {
+		if aws.ToString(assoc.AssociatedEntity) != entity {
+			continue
+		}
+		if assoc.Status == svcsdktypes.ResourceShareAssociationStatusDisassociated {
+			continue
+		}
+		filtered = append(filtered, assoc)
+	}
+	return filtered
+}
+
+// associationFailed returns a terminal error carrying the status message
+// of the association if RAM failed to associate the entity with the
+// resource share.
+func associationFailed(ko *svcapitypes.ResourceShareAssociation) error {
+	if ko.Status.Status == nil ||
+		*ko.Status.Status != string(svcapitypes.ResourceShareAssociationStatus_SDK_FAILED) {
+		return nil
+	}
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"failed to associate %s with resource share %s: %s",
+		associatedEntity(ko),
+		aws.ToString(ko.Spec.ResourceShareARN),
+		aws.ToString(ko.Status.StatusMessage),
+	))
+}
+
+// setResourceShareAssociation copies the fields of the supplied association
+// into the resource.
+func setResourceShareAssociation(
+	ko *svcapitypes.ResourceShareAssociation,
+	assoc *svcsdktypes.ResourceShareAssociation,
+) {
+	if assoc.AssociationType != "" {
+		ko.Status.AssociationType = aws.String(string(assoc.AssociationType))
+	} else {
+		ko.Status.AssociationType = nil
+	}
+	if assoc.CreationTime != nil {
+		ko.Status.CreationTime = &metav1.Time{Time: *assoc.CreationTime}
+	} else {
+		ko.Status.CreationTime = nil
+	}
+	ko.Status.External = assoc.External
+	if assoc.LastUpdatedTime != nil {
+		ko.Status.LastUpdatedTime = &metav1.Time{Time: *assoc.LastUpdatedTime}
+	} else {
+		ko.Status.LastUpdatedTime = nil
+	}
+	ko.Status.ResourceShareName = assoc.ResourceShareName
+	if assoc.Status != "" {
+		ko.Status.Status = aws.String(string(assoc.Status))
+	} else {
+		ko.Status.Status = nil
+	}
+	ko.Status.StatusMessage = assoc.StatusMessage
+}
@@ -0,0 +1,322 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share_invitation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ram"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+	svcconfig "github.com/aws-controllers-k8s/ram-controller/pkg/config"
+)
+
+const (
+	ActionAccept = "ACCEPT"
+	ActionReject = "REJECT"
+)
+
+var (
+	// requeueWaitingForInvitation is the delay before looking for a pending
+	// invitation again when the sender hasn't shared the resource share
+	// with this account yet.
+	requeueWaitingForInvitation = 30 * time.Second
+)
+
+// listPageSize returns the MaxResults of the RAM list calls.
+func listPageSize() *int32 {
+	return aws.Int32(svcconfig.ListPageSize)
+}
+
+// listLimitExceeded returns the terminal error reported when the supplied
+// RAM list operation has more pages than the controller is allowed to read.
+func listLimitExceeded(operation string) error {
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"%s returned more than %d pages of %d items, increase --%s to manage this invitation",
+		operation, svcconfig.ListMaxPages, svcconfig.ListPageSize,
+		svcconfig.FlagListMaxPages,
+	))
+}
+
+// getRemainingResourceShareInvitations follows the NextToken of the
+// supplied GetResourceShareInvitations response and appends the
+// invitations of the following pages to it.
+func (rm *resourceManager) getRemainingResourceShareInvitations(
+	ctx context.Context,
+	input *svcsdk.GetResourceShareInvitationsInput,
+	resp *svcsdk.GetResourceShareInvitationsOutput,
+) (err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.getRemainingResourceShareInvitations")
+	defer func() {
+		exit(err)
+	}()
+	for page := 1; resp.NextToken != nil; page++ {
+		if page >= svcconfig.ListMaxPages {
+			return listLimitExceeded("GetResourceShareInvitations")
+		}
+		input.NextToken = resp.NextToken
+		var next *svcsdk.GetResourceShareInvitationsOutput
+		next, err = rm.sdkapi.GetResourceShareInvitations(ctx, input)
+		rm.metrics.RecordAPICall("READ_MANY", "GetResourceShareInvitations", err)
+		if err != nil {
+			return err
+		}
+		resp.ResourceShareInvitations = append(resp.ResourceShareInvitations, next.ResourceShareInvitations...)
+		resp.NextToken = next.NextToken
+	}
+	return nil
+}
+
+// desiredAction returns the action the user asked for, defaulting to
+// ACCEPT when none is set.
+func desiredAction(ko *svcapitypes.ResourceShareInvitation) string {
+	if ko.Spec.Action == nil {
+		return ActionAccept
+	}
+	return *ko.Spec.Action
+}
+
+// validateAction returns a terminal error if Spec.Action isn't one of the
+// supported values.
+func validateAction(r *resource) error {
+	switch desiredAction(r.ko) {
+	case ActionAccept, ActionReject:
+		return nil
+	}
+	return ackerr.NewTerminalError(fmt.Errorf(
+		"invalid action %q: must be one of %s, %s",
+		*r.ko.Spec.Action, ActionAccept, ActionReject,
+	))
+}
+
+func actionIsReject(r *resource) bool {
+	return desiredAction(r.ko) == ActionReject
+}
+
+// invitationPending returns true if the invitation hasn't been accepted or
+// rejected yet.
+func invitationPending(ko *svcapitypes.ResourceShareInvitation) bool {
+	return ko.Status.Status != nil &&
+		*ko.Status.Status == string(svcapitypes.ResourceShareInvitationStatus_SDK_PENDING)
+}
+
+// setObservedAction records the response RAM holds for the invitation in
+// Spec.Action of the latest resource, so that a desired action that
+// contradicts it shows up in the delta. An expired invitation can't be
+// responded to anymore and results in a terminal error, which the caller
+// ignores while the resource is being deleted so that its finalizer can be
+// removed.
+func setObservedAction(ko *svcapitypes.ResourceShareInvitation) error {
+	if ko.Status.Status == nil {
+		return nil
+	}
+	var observed string
+	switch svcapitypes.ResourceShareInvitationStatus_SDK(*ko.Status.Status) {
+	case svcapitypes.ResourceShareInvitationStatus_SDK_ACCEPTED:
+		observed = ActionAccept
+	case svcapitypes.ResourceShareInvitationStatus_SDK_REJECTED:
+		observed = ActionReject
+	case svcapitypes.ResourceShareInvitationStatus_SDK_EXPIRED:
+		return ackerr.NewTerminalError(fmt.Errorf(
+			"the invitation to resource share %s has expired",
+			aws.ToString(ko.Spec.ResourceShareARN),
+		))
+	default:
+		return nil
+	}
+	if desiredAction(ko) != observed {
+		ko.Spec.Action = &observed
+	}
+	return nil
+}
+
+// filterInvitations drops the invitations that weren't sent by the account
+// in Spec.SenderAccountID and orders the remaining ones so that the
+// invitation the resource already responded to, or else the most recent
+// one, comes first.
+func filterInvitations(
+	r *resource,
+	invitations []svcsdktypes.ResourceShareInvitation,
+) []svcsdktypes.ResourceShareInvitation {
+	knownARN := ""
+	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
+		knownARN = string(*r.ko.Status.ACKResourceMetadata.ARN)
+	}
+	filtered := []svcsdktypes.ResourceShareInvitation{}
+	for _, inv := range invitations {
+		if r.ko.Spec.SenderAccountID != nil &&
+			aws.ToString(inv.SenderAccountId) != *r.ko.Spec.SenderAccountID {
+			continue
+		}
+		if knownARN != "" && aws.ToString(inv.ResourceShareInvitationArn) == knownARN {
+			return []svcsdktypes.ResourceShareInvitation{inv}
+		}
+		filtered = append(filtered, inv)
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		ti, tj := filtered[i].InvitationTimestamp, filtered[j].InvitationTimestamp
+		if ti == nil || tj == nil {
+			return ti != nil
+		}
+		return ti.After(*tj)
+	})
+	return filtered
+}
+
+// getPendingInvitationARN returns the ARN of the pending invitation for the
+// resource share in Spec.ResourceShareARN. If there is none yet, the
+// resource is requeued until the sender shares the resource share with
+// this account.
+func (rm *resourceManager) getPendingInvitationARN(
+	ctx context.Context,
+	r *resource,
+) (arn *string, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.getPendingInvitationARN")
+	defer func() {
+		exit(err)
+	}()
+
+	input := &svcsdk.GetResourceShareInvitationsInput{
+		ResourceShareArns: []string{*r.ko.Spec.ResourceShareARN},
+		MaxResults:        listPageSize(),
+	}
+	resp, err := rm.sdkapi.GetResourceShareInvitations(ctx, input)
+	rm.metrics.RecordAPICall("READ_MANY", "GetResourceShareInvitations", err)
+	if err != nil {
+		return nil, err
+	}
+	if err = rm.getRemainingResourceShareInvitations(ctx, input, resp); err != nil {
+		return nil, err
+	}
+	for _, inv := range filterInvitations(r, resp.ResourceShareInvitations) {
+		if inv.Status == svcsdktypes.ResourceShareInvitationStatusPending {
+			return inv.ResourceShareInvitationArn, nil
+		}
+	}
+	return nil, ackrequeue.NeededAfter(
+		fmt.Errorf(
+			"no pending invitation found for resource share %s",
+			*r.ko.Spec.ResourceShareARN,
+		),
+		requeueWaitingForInvitation,
+	)
+}
+
+// rejectInvitation rejects the pending invitation with the supplied ARN
+// and returns a copy of the resource with the fields of the rejected
+// invitation filled in.
+func (rm *resourceManager) rejectInvitation(
+	ctx context.Context,
+	desired *resource,
+	invitationARN *string,
+) (created *resource, err error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.rejectInvitation")
+	defer func() {
+		exit(err)
+	}()
+
+	resp, err := rm.sdkapi.RejectResourceShareInvitation(
+		ctx,
+		&svcsdk.RejectResourceShareInvitationInput{
+			ResourceShareInvitationArn: invitationARN,
+		},
+	)
+	rm.metrics.RecordAPICall("CREATE", "RejectResourceShareInvitation", err)
+	if err != nil {
+		return nil, err
+	}
+
+	ko := desired.ko.DeepCopy()
+	if resp.ResourceShareInvitation != nil {
+		setResourceShareInvitation(ko, resp.ResourceShareInvitation)
+	}
+	rm.setStatusDefaults(ko)
+	return &resource{ko}, nil
+}
+
+// setResourceShareInvitation copies the fields of the supplied invitation
+// into the resource.
+func setResourceShareInvitation(
+	ko *svcapitypes.ResourceShareInvitation,
+	inv *svcsdktypes.ResourceShareInvitation,
+) {
+	if inv.InvitationTimestamp != nil {
+		ko.Status.InvitationTimestamp = &metav1.Time{Time: *inv.InvitationTimestamp}
+	} else {
+		ko.Status.InvitationTimestamp = nil
+	}
+	ko.Status.ReceiverAccountID = inv.ReceiverAccountId
+	ko.Status.ReceiverARN = inv.ReceiverArn
+	ko.Status.ResourceShareName = inv.ResourceShareName
+	if inv.ResourceShareArn != nil {
+		ko.Spec.ResourceShareARN = inv.ResourceShareArn
+	}
+	if inv.SenderAccountId != nil {
+		ko.Spec.SenderAccountID = inv.SenderAccountId
+	}
+	if inv.ResourceShareInvitationArn != nil {
+		if ko.Status.ACKResourceMetadata == nil {
+			ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{}
+		}
+		arn := ackv1alpha1.AWSResourceName(*inv.ResourceShareInvitationArn)
+		ko.Status.ACKResourceMetadata.ARN = &arn
+	}
+	if inv.Status != "" {
+		ko.Status.Status = aws.String(string(inv.Status))
+	} else {
+		ko.Status.Status = nil
+	}
+}
+
+// customUpdateResourceShareInvitation is called when the desired state of
+// the invitation differs from the one observed in RAM. RAM doesn't allow
+// changing the response to an invitation, so a different Spec.Action is
+// reported as a terminal error. Any other difference comes from fields RAM
+// filled in, which are copied into the resource.
+func (rm *resourceManager) customUpdateResourceShareInvitation(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) (*resource, error) {
+	if err := validateAction(desired); err != nil {
+		return nil, err
+	}
+	if delta.DifferentAt("Spec.Action") {
+		return nil, ackerr.NewTerminalError(fmt.Errorf(
+			"the invitation to resource share %s was already responded to with %s, "+
+				"the response to an invitation can't be changed",
+			aws.ToString(latest.ko.Spec.ResourceShareARN), desiredAction(latest.ko),
+		))
+	}
+
+	ko := latest.ko.DeepCopy()
+	ko.Spec.Action = desired.ko.Spec.Action
+	rm.setStatusDefaults(ko)
+	return &resource{ko}, nil
+}
@@ -0,0 +1,192 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_share_invitation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ram/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+func TestFilterInvitations(t *testing.T) {
+	now := time.Now()
+	invitation := func(arn, sender string, sent *time.Time) svcsdktypes.ResourceShareInvitation {
+		return svcsdktypes.ResourceShareInvitation{
+			ResourceShareInvitationArn: aws.String(arn),
+			SenderAccountId:            aws.String(sender),
+			InvitationTimestamp:        sent,
+		}
+	}
+	older := now.Add(-time.Hour)
+	tests := []struct {
+		name        string
+		sender      *string
+		knownARN    string
+		invitations []svcsdktypes.ResourceShareInvitation
+		wantARNs    []string
+	}{
+		{
+			name: "most recent invitation comes first",
+			invitations: []svcsdktypes.ResourceShareInvitation{
+				invitation("older", "111122223333", &older),
+				invitation("newer", "444455556666", &now),
+			},
+			wantARNs: []string{"newer", "older"},
+		},
+		{
+			name: "invitations without a timestamp come last",
+			invitations: []svcsdktypes.ResourceShareInvitation{
+				invitation("undated", "111122223333", nil),
+				invitation("older", "111122223333", &older),
+			},
+			wantARNs: []string{"older", "undated"},
+		},
+		{
+			name:   "invitations of other senders are dropped",
+			sender: aws.String("111122223333"),
+			invitations: []svcsdktypes.ResourceShareInvitation{
+				invitation("older", "111122223333", &older),
+				invitation("newer", "444455556666", &now),
+			},
+			wantARNs: []string{"older"},
+		},
+		{
+			name:     "invitation already responded to is the only one kept",
+			knownARN: "older",
+			invitations: []svcsdktypes.ResourceShareInvitation{
+				invitation("newer", "111122223333", &now),
+				invitation("older", "111122223333", &older),
+			},
+			wantARNs: []string{"older"},
+		},
+		{
+			name:     "invitation already responded to from another sender",
+			sender:   aws.String("111122223333"),
+			knownARN: "other",
+			invitations: []svcsdktypes.ResourceShareInvitation{
+				invitation("other", "444455556666", &now),
+				invitation("older", "111122223333", &older),
+			},
+			wantARNs: []string{"older"},
+		},
+		{
+			name:        "no invitations",
+			invitations: nil,
+			wantARNs:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShareInvitation{
+				Spec: svcapitypes.ResourceShareInvitationSpec{SenderAccountID: tt.sender},
+			}
+			if tt.knownARN != "" {
+				arn := ackv1alpha1.AWSResourceName(tt.knownARN)
+				ko.Status.ACKResourceMetadata = &ackv1alpha1.ResourceMetadata{ARN: &arn}
+			}
+			arns := []string{}
+			for _, inv := range filterInvitations(&resource{ko}, tt.invitations) {
+				arns = append(arns, aws.ToString(inv.ResourceShareInvitationArn))
+			}
+			if !reflect.DeepEqual(arns, tt.wantARNs) {
+				t.Errorf("filterInvitations() = %v, want %v", arns, tt.wantARNs)
+			}
+		})
+	}
+}
+
+func TestSetObservedAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     *string
+		status     *string
+		wantAction *string
+		wantErr    bool
+	}{
+		{
+			name:       "pending invitation keeps the desired action",
+			action:     aws.String(ActionReject),
+			status:     aws.String(string(svcapitypes.ResourceShareInvitationStatus_SDK_PENDING)),
+			wantAction: aws.String(ActionReject),
+		},
+		{
+			name:       "accepted invitation with the default action",
+			status:     aws.String(string(svcapitypes.ResourceShareInvitationStatus_SDK_ACCEPTED)),
+			wantAction: nil,
+		},
+		{
+			name:       "accepted invitation the user wants rejected",
+			action:     aws.String(ActionReject),
+			status:     aws.String(string(svcapitypes.ResourceShareInvitationStatus_SDK_ACCEPTED)),
+			wantAction: aws.String(ActionAccept),
+		},
+		{
+			name:       "rejected invitation the user wants accepted",
+			status:     aws.String(string(svcapitypes.ResourceShareInvitationStatus_SDK_REJECTED)),
+			wantAction: aws.String(ActionReject),
+		},
+		{
+			name:    "expired invitation",
+			status:  aws.String(string(svcapitypes.ResourceShareInvitationStatus_SDK_EXPIRED)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShareInvitation{
+				Spec:   svcapitypes.ResourceShareInvitationSpec{Action: tt.action},
+				Status: svcapitypes.ResourceShareInvitationStatus{Status: tt.status},
+			}
+			err := setObservedAction(ko)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setObservedAction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(ko.Spec.Action, tt.wantAction) {
+				t.Errorf("setObservedAction() action = %v, want %v", aws.ToString(ko.Spec.Action), aws.ToString(tt.wantAction))
+			}
+		})
+	}
+}
+
+func TestValidateAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  *string
+		wantErr bool
+	}{
+		{name: "default"},
+		{name: "accept", action: aws.String(ActionAccept)},
+		{name: "reject", action: aws.String(ActionReject)},
+		{name: "lower case", action: aws.String("accept"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resource{&svcapitypes.ResourceShareInvitation{
+				Spec: svcapitypes.ResourceShareInvitationSpec{Action: tt.action},
+			}}
+			if err := validateAction(r); (err != nil) != tt.wantErr {
+				t.Errorf("validateAction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_selector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+// PrincipalSetKey returns the key of the PrincipalSet the supplied
+// reference of the resource share points to. The principal set is in the
+// namespace of the resource share unless the reference specifies one.
+func PrincipalSetKey(
+	ko *svcapitypes.ResourceShare,
+	ref *ackv1alpha1.AWSResourceReferenceWrapper,
+) types.NamespacedName {
+	key := types.NamespacedName{Namespace: ko.GetNamespace()}
+	if ref.From.Namespace != nil && *ref.From.Namespace != "" {
+		key.Namespace = *ref.From.Namespace
+	}
+	if ref.From.Name != nil {
+		key.Name = *ref.From.Name
+	}
+	return key
+}
+
+// referencesPrincipalSet returns whether the supplied resource share
+// references the supplied principal set.
+func referencesPrincipalSet(ko *svcapitypes.ResourceShare, obj client.Object) bool {
+	for _, ref := range ko.Spec.PrincipalSetRefs {
+		if ref != nil && ref.From != nil &&
+			PrincipalSetKey(ko, ref) == client.ObjectKeyFromObject(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// principalSetGenerations returns the generations of the principal sets the
+// supplied resource share references, which change along with their
+// principals.
+func principalSetGenerations(
+	ctx context.Context,
+	reader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) (string, error) {
+	generations := []string{}
+	for _, ref := range ko.Spec.PrincipalSetRefs {
+		if ref == nil || ref.From == nil {
+			continue
+		}
+		key := PrincipalSetKey(ko, ref)
+		set := &svcapitypes.PrincipalSet{}
+		if err := reader.Get(ctx, key, set); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return "", err
+			}
+			// A missing principal set is reported by the ResourceShare
+			// reconciler, and counts as generation 0.
+		}
+		generations = append(generations, fmt.Sprintf("%s=%d", key, set.Generation))
+	}
+	return strings.Join(generations, ","), nil
+}
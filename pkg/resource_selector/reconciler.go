@@ -0,0 +1,213 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package resource_selector resolves the resource selectors of
+// ResourceShare resources, and contains the reconciler that watches the
+// kinds they select and the principal sets they reference. The
+// ResourceShare controller only reacts to changes of the generation of a
+// resource share, so this reconciler records a digest of the resources a
+// resource share selects and of the principal sets it references in
+// Spec.SelectionRevision, which makes the ResourceShare controller reconcile
+// the resource share whenever they change.
+package resource_selector
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+const selectorControllerName = "resourceshareselector"
+
+// Reconciler watches the kinds selected by the resource selectors of the
+// resource shares and the principal sets they reference, and updates
+// Spec.SelectionRevision of the resource shares whose selected resources or
+// principal sets changed.
+type Reconciler struct {
+	kc    client.Client
+	cache cache.Cache
+	ctrl  controller.Controller
+	log   logr.Logger
+
+	mu sync.Mutex
+	// watched holds the kinds the reconciler watches.
+	watched map[schema.GroupVersionKind]bool
+}
+
+// SetupWithManager creates a Reconciler for the resource shares of the
+// supplied service controller, and registers it with the supplied manager.
+// It must be called once the service controller is bound to the manager.
+func SetupWithManager(
+	mgr ctrlrt.Manager,
+	sc acktypes.ServiceController,
+) error {
+	r := &Reconciler{
+		kc:      mgr.GetClient(),
+		cache:   mgr.GetCache(),
+		log:     ctrlrt.Log.WithName("ackrt").WithName(selectorControllerName),
+		watched: map[schema.GroupVersionKind]bool{},
+	}
+	gvk := svcapitypes.GroupVersion.WithKind("ResourceShare")
+	bound := false
+	for _, rec := range sc.GetReconcilers() {
+		if *rec.GroupVersionKind() == gvk {
+			bound = true
+		}
+	}
+	if !bound {
+		return errors.New("ResourceShare reconciler is not bound to the manager")
+	}
+	var err error
+	r.ctrl, err = ctrlrt.NewControllerManagedBy(mgr).
+		Named(selectorControllerName).
+		For(
+			&svcapitypes.ResourceShare{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&svcapitypes.PrincipalSet{},
+			handler.EnqueueRequestsFromMapFunc(r.referencingShares),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Build(r)
+	return err
+}
+
+// Reconcile makes sure the kinds selected by the resource share are watched
+// and updates its Spec.SelectionRevision if the resources it selects or the
+// principal sets it references changed. The update changes the generation
+// of the resource share, which is what the ResourceShare controller reacts
+// to.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrlrt.Request) (ctrlrt.Result, error) {
+	share := &svcapitypes.ResourceShare{}
+	if err := r.kc.Get(ctx, req.NamespacedName, share); client.IgnoreNotFound(err) != nil {
+		return ctrlrt.Result{}, err
+	}
+	if share.Name == "" || !share.DeletionTimestamp.IsZero() ||
+		(len(share.Spec.ResourceSelectors) == 0 && len(share.Spec.PrincipalSetRefs) == 0) {
+		return ctrlrt.Result{}, nil
+	}
+	for _, sel := range share.Spec.ResourceSelectors {
+		// Invalid selectors are reported by the ResourceShare reconciler.
+		if Validate(sel) != nil {
+			continue
+		}
+		if err := r.watch(GroupVersionKind(sel)); err != nil {
+			return ctrlrt.Result{}, err
+		}
+	}
+
+	arns, err := SelectedARNs(ctx, r.kc, share)
+	if err != nil {
+		return ctrlrt.Result{}, err
+	}
+	generations, err := principalSetGenerations(ctx, r.kc, share)
+	if err != nil {
+		return ctrlrt.Result{}, err
+	}
+	revision := selectionRevision(aws.ToStringSlice(arns), generations)
+	if aws.ToString(share.Spec.SelectionRevision) == revision {
+		return ctrlrt.Result{}, nil
+	}
+	r.log.V(1).Info(
+		"resources selected or principal sets referenced by resource share changed",
+		"namespace", req.Namespace, "name", req.Name,
+	)
+	patch := client.MergeFrom(share.DeepCopy())
+	share.Spec.SelectionRevision = aws.String(revision)
+	return ctrlrt.Result{}, client.IgnoreNotFound(r.kc.Patch(ctx, share, patch))
+}
+
+// selectionRevision returns the digest of the supplied selected ARNs and
+// principal set generations recorded in Spec.SelectionRevision.
+func selectionRevision(arns []string, generations string) string {
+	sum := sha256.Sum256([]byte(strings.Join(arns, ",") + ";" + generations))
+	return hex.EncodeToString(sum[:])
+}
+
+// watch starts watching the supplied kind, unless it is already watched.
+func (r *Reconciler) watch(gvk schema.GroupVersionKind) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.watched[gvk] {
+		return nil
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := r.ctrl.Watch(source.Kind[client.Object](
+		r.cache, obj, handler.EnqueueRequestsFromMapFunc(r.selectingShares),
+	)); err != nil {
+		return err
+	}
+	r.log.Info("watching resources selected by resource shares", "kind", gvk.String())
+	r.watched[gvk] = true
+	return nil
+}
+
+// selectingShares returns the requests for the resource shares selecting
+// the supplied object.
+func (r *Reconciler) selectingShares(ctx context.Context, obj client.Object) []reconcile.Request {
+	shares := &svcapitypes.ResourceShareList{}
+	if err := r.kc.List(ctx, shares); err != nil {
+		r.log.Error(err, "failed to list resource shares")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range shares.Items {
+		if Selects(&shares.Items[i], obj) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&shares.Items[i]),
+			})
+		}
+	}
+	return requests
+}
+
+// referencingShares returns the requests for the resource shares
+// referencing the supplied principal set.
+func (r *Reconciler) referencingShares(ctx context.Context, obj client.Object) []reconcile.Request {
+	shares := &svcapitypes.ResourceShareList{}
+	if err := r.kc.List(ctx, shares); err != nil {
+		r.log.Error(err, "failed to list resource shares")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range shares.Items {
+		if referencesPrincipalSet(&shares.Items[i], obj) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&shares.Items[i]),
+			})
+		}
+	}
+	return requests
+}
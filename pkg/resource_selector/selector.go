@@ -0,0 +1,206 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_selector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+const (
+	// ackAPIGroupSuffix is the suffix of the API groups of the ACK services.
+	ackAPIGroupSuffix = ".services.k8s.aws"
+	// defaultVersion is the API version of the selected resources when the
+	// selector doesn't specify one.
+	defaultVersion = "v1alpha1"
+)
+
+// selectedResourceStatus is the part of the status shared by every ACK
+// resource that is needed to associate it with a resource share.
+type selectedResourceStatus struct {
+	Status struct {
+		ACKResourceMetadata *ackv1alpha1.ResourceMetadata `json:"ackResourceMetadata"`
+		Conditions          []*ackv1alpha1.Condition      `json:"conditions"`
+	} `json:"status"`
+}
+
+// ForbiddenError returns an error telling which access the controller lacks
+// when the supplied error is the API server forbidding it to read the
+// resources of the supplied kind referenced by the supplied field, and the
+// supplied error otherwise.
+func ForbiddenError(field string, gvk schema.GroupVersionKind, err error) error {
+	if !apierrors.IsForbidden(err) {
+		return err
+	}
+	return fmt.Errorf(
+		"%s: the controller isn't allowed to read %s resources, grant its ClusterRole get, list and watch on the resources of the %s API group: %w",
+		field, gvk.Kind, gvk.Group, err,
+	)
+}
+
+// Validate returns an error unless the supplied selector selects a kind of
+// ACK resources with a valid label selector.
+func Validate(sel *svcapitypes.ResourceSelector) error {
+	if sel == nil || sel.Group == nil || *sel.Group == "" ||
+		sel.Kind == nil || *sel.Kind == "" {
+		return fmt.Errorf("provided resource selector is nil or empty: ResourceSelectors")
+	}
+	if !strings.HasSuffix(*sel.Group, ackAPIGroupSuffix) {
+		return fmt.Errorf(
+			"provided resource selector doesn't select ACK resources: ResourceSelectors: group %s doesn't end with %s",
+			*sel.Group, ackAPIGroupSuffix,
+		)
+	}
+	if _, err := labelSelector(sel); err != nil {
+		return fmt.Errorf("invalid label selector: ResourceSelectors: %v", err)
+	}
+	return nil
+}
+
+// GroupVersionKind returns the kind of the resources the supplied selector
+// selects.
+func GroupVersionKind(sel *svcapitypes.ResourceSelector) schema.GroupVersionKind {
+	version := defaultVersion
+	if sel.Version != nil && *sel.Version != "" {
+		version = *sel.Version
+	}
+	gvk := schema.GroupVersionKind{Version: version}
+	if sel.Group != nil {
+		gvk.Group = *sel.Group
+	}
+	if sel.Kind != nil {
+		gvk.Kind = *sel.Kind
+	}
+	return gvk
+}
+
+// namespace returns the namespace of the resources the supplied selector of
+// the resource share selects.
+func namespace(
+	ko *svcapitypes.ResourceShare,
+	sel *svcapitypes.ResourceSelector,
+) string {
+	if sel.Namespace != nil && *sel.Namespace != "" {
+		return *sel.Namespace
+	}
+	return ko.GetNamespace()
+}
+
+// labelSelector returns the label selector of the supplied selector. A
+// selector without a label selector selects everything.
+func labelSelector(sel *svcapitypes.ResourceSelector) (labels.Selector, error) {
+	if sel.LabelSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(sel.LabelSelector)
+}
+
+// Selects returns whether one of the selectors of the supplied resource
+// share selects the supplied object, whatever its state.
+func Selects(ko *svcapitypes.ResourceShare, obj client.Object) bool {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	for _, sel := range ko.Spec.ResourceSelectors {
+		if Validate(sel) != nil || GroupVersionKind(sel) != gvk ||
+			namespace(ko, sel) != obj.GetNamespace() {
+			continue
+		}
+		selector, _ := labelSelector(sel)
+		if selector.Matches(labels.Set(obj.GetLabels())) {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectedARNs returns the sorted ARNs of the resources selected by the
+// selectors of the supplied resource share. The resources that aren't
+// synced yet, or are in a terminal state, are left out until they are.
+func SelectedARNs(
+	ctx context.Context,
+	reader client.Reader,
+	ko *svcapitypes.ResourceShare,
+) ([]*string, error) {
+	seen := map[string]bool{}
+	arns := []string{}
+	for _, sel := range ko.Spec.ResourceSelectors {
+		if err := Validate(sel); err != nil {
+			return nil, err
+		}
+		selector, _ := labelSelector(sel)
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(GroupVersionKind(sel).GroupVersion().WithKind(*sel.Kind + "List"))
+		if err := reader.List(
+			ctx, list,
+			client.InNamespace(namespace(ko, sel)),
+			client.MatchingLabelsSelector{Selector: selector},
+		); err != nil {
+			return nil, ForbiddenError("ResourceSelectors", GroupVersionKind(sel), err)
+		}
+		for i := range list.Items {
+			arn, err := syncedARN(&list.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			if arn != "" && !seen[arn] {
+				seen[arn] = true
+				arns = append(arns, arn)
+			}
+		}
+	}
+	sort.Strings(arns)
+	selected := make([]*string, 0, len(arns))
+	for i := range arns {
+		selected = append(selected, &arns[i])
+	}
+	return selected, nil
+}
+
+// syncedARN returns the ARN of the supplied ACK resource, or an empty string
+// if the resource isn't synced or is in a terminal state.
+func syncedARN(obj *unstructured.Unstructured) (string, error) {
+	var state selectedResourceStatus
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &state); err != nil {
+		return "", err
+	}
+	synced := false
+	for _, cond := range state.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case ackv1alpha1.ConditionTypeTerminal:
+			return "", nil
+		case ackv1alpha1.ConditionTypeResourceSynced:
+			synced = true
+		}
+	}
+	if !synced || state.Status.ACKResourceMetadata == nil || state.Status.ACKResourceMetadata.ARN == nil {
+		return "", nil
+	}
+	return string(*state.Status.ACKResourceMetadata.ARN), nil
+}
@@ -0,0 +1,313 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package resource_selector
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+var subnetGVK = schema.GroupVersionKind{
+	Group:   "ec2.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "Subnet",
+}
+
+// subnet returns an ACK Subnet with the supplied labels, ARN and conditions.
+func subnet(
+	namespace string,
+	name string,
+	labels map[string]string,
+	arn string,
+	conditions ...ackv1alpha1.ConditionType,
+) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetGroupVersionKind(subnetGVK)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	status := map[string]interface{}{}
+	if arn != "" {
+		status["ackResourceMetadata"] = map[string]interface{}{"arn": arn}
+	}
+	conds := []interface{}{}
+	for _, cond := range conditions {
+		conds = append(conds, map[string]interface{}{
+			"type":   string(cond),
+			"status": string(corev1.ConditionTrue),
+		})
+	}
+	status["conditions"] = conds
+	obj.Object["status"] = status
+	return obj
+}
+
+// selectorFor returns a selector of the Subnets with the supplied labels.
+func selectorFor(namespace string, labels map[string]string) *svcapitypes.ResourceSelector {
+	sel := &svcapitypes.ResourceSelector{
+		Group: aws.String(subnetGVK.Group),
+		Kind:  aws.String(subnetGVK.Kind),
+	}
+	if namespace != "" {
+		sel.Namespace = aws.String(namespace)
+	}
+	if labels != nil {
+		sel.LabelSelector = &metav1.LabelSelector{MatchLabels: labels}
+	}
+	return sel
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sel     *svcapitypes.ResourceSelector
+		wantErr bool
+	}{
+		{name: "valid", sel: selectorFor("", map[string]string{"team": "a"})},
+		{name: "without label selector", sel: selectorFor("", nil)},
+		{name: "nil", sel: nil, wantErr: true},
+		{name: "without kind", sel: &svcapitypes.ResourceSelector{Group: aws.String(subnetGVK.Group)}, wantErr: true},
+		{
+			name:    "not an ACK group",
+			sel:     &svcapitypes.ResourceSelector{Group: aws.String("apps"), Kind: aws.String("Deployment")},
+			wantErr: true,
+		},
+		{
+			name: "invalid label selector",
+			sel: &svcapitypes.ResourceSelector{
+				Group: aws.String(subnetGVK.Group),
+				Kind:  aws.String(subnetGVK.Kind),
+				LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "team",
+					Operator: "Near",
+				}}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.sel); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelects(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors []*svcapitypes.ResourceSelector
+		obj       client.Object
+		want      bool
+	}{
+		{
+			name:      "matching labels in the namespace of the resource share",
+			selectors: []*svcapitypes.ResourceSelector{selectorFor("", map[string]string{"team": "a"})},
+			obj:       subnet("team", "subnet", map[string]string{"team": "a"}, ""),
+			want:      true,
+		},
+		{
+			name:      "other labels",
+			selectors: []*svcapitypes.ResourceSelector{selectorFor("", map[string]string{"team": "a"})},
+			obj:       subnet("team", "subnet", map[string]string{"team": "b"}, ""),
+		},
+		{
+			name:      "another namespace",
+			selectors: []*svcapitypes.ResourceSelector{selectorFor("", map[string]string{"team": "a"})},
+			obj:       subnet("other", "subnet", map[string]string{"team": "a"}, ""),
+		},
+		{
+			name:      "namespace of the selector",
+			selectors: []*svcapitypes.ResourceSelector{selectorFor("other", nil)},
+			obj:       subnet("other", "subnet", nil, ""),
+			want:      true,
+		},
+		{
+			name: "another kind",
+			selectors: []*svcapitypes.ResourceSelector{{
+				Group: aws.String(subnetGVK.Group),
+				Kind:  aws.String("VPC"),
+			}},
+			obj: subnet("team", "subnet", nil, ""),
+		},
+		{
+			name: "invalid selector",
+			selectors: []*svcapitypes.ResourceSelector{{
+				Group: aws.String("ec2"),
+				Kind:  aws.String(subnetGVK.Kind),
+			}},
+			obj: subnet("team", "subnet", nil, ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{
+				ObjectMeta: metav1.ObjectMeta{Name: "share", Namespace: "team"},
+				Spec:       svcapitypes.ResourceShareSpec{ResourceSelectors: tt.selectors},
+			}
+			if got := Selects(ko, tt.obj); got != tt.want {
+				t.Errorf("Selects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectedARNs(t *testing.T) {
+	const (
+		first  = "arn:aws:ec2:us-west-2:111122223333:subnet/subnet-1"
+		second = "arn:aws:ec2:us-west-2:111122223333:subnet/subnet-2"
+	)
+	synced := ackv1alpha1.ConditionTypeResourceSynced
+	terminal := ackv1alpha1.ConditionTypeTerminal
+	objects := []client.Object{
+		subnet("team", "second", map[string]string{"team": "a"}, second, synced),
+		subnet("team", "first", map[string]string{"team": "a", "tier": "private"}, first, synced),
+		subnet("team", "unsynced", map[string]string{"team": "a"}, "arn:aws:ec2:us-west-2:111122223333:subnet/subnet-3"),
+		subnet("team", "terminal", map[string]string{"team": "a"}, "arn:aws:ec2:us-west-2:111122223333:subnet/subnet-4", synced, terminal),
+		subnet("team", "without-arn", map[string]string{"team": "a"}, "", synced),
+		subnet("other", "elsewhere", map[string]string{"team": "a"}, "arn:aws:ec2:us-west-2:111122223333:subnet/subnet-5", synced),
+	}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(subnetGVK, meta.RESTScopeNamespace)
+	reader := fake.NewClientBuilder().
+		WithScheme(runtime.NewScheme()).
+		WithRESTMapper(mapper).
+		WithObjects(objects...).
+		Build()
+
+	tests := []struct {
+		name      string
+		selectors []*svcapitypes.ResourceSelector
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "synced resources with the labels, sorted",
+			selectors: []*svcapitypes.ResourceSelector{selectorFor("", map[string]string{"team": "a"})},
+			want:      []string{first, second},
+		},
+		{
+			name:      "narrower label selector",
+			selectors: []*svcapitypes.ResourceSelector{selectorFor("", map[string]string{"tier": "private"})},
+			want:      []string{first},
+		},
+		{
+			name: "overlapping selectors select a resource once",
+			selectors: []*svcapitypes.ResourceSelector{
+				selectorFor("", map[string]string{"team": "a"}),
+				selectorFor("", map[string]string{"tier": "private"}),
+			},
+			want: []string{first, second},
+		},
+		{
+			name:      "no matching resources",
+			selectors: []*svcapitypes.ResourceSelector{selectorFor("", map[string]string{"team": "b"})},
+			want:      []string{},
+		},
+		{
+			name:      "invalid selector",
+			selectors: []*svcapitypes.ResourceSelector{{Group: aws.String("ec2"), Kind: aws.String("Subnet")}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{
+				ObjectMeta: metav1.ObjectMeta{Name: "share", Namespace: "team"},
+				Spec:       svcapitypes.ResourceShareSpec{ResourceSelectors: tt.selectors},
+			}
+			got, err := SelectedARNs(context.Background(), reader, ko)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectedARNs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(aws.ToStringSlice(got), tt.want) {
+				t.Errorf("SelectedARNs() = %v, want %v", aws.ToStringSlice(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectionRevision(t *testing.T) {
+	base := selectionRevision([]string{"a", "b"}, "set=1")
+	tests := []struct {
+		name        string
+		arns        []string
+		generations string
+		wantSame    bool
+	}{
+		{name: "same selection", arns: []string{"a", "b"}, generations: "set=1", wantSame: true},
+		{name: "resource deselected", arns: []string{"a"}, generations: "set=1"},
+		{name: "resource selected", arns: []string{"a", "b", "c"}, generations: "set=1"},
+		{name: "principal set changed", arns: []string{"a", "b"}, generations: "set=2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectionRevision(tt.arns, tt.generations); (got == base) != tt.wantSame {
+				t.Errorf("selectionRevision() = %s, same as %s = %v, want %v", got, base, got == base, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestForbiddenError(t *testing.T) {
+	resource := schema.GroupResource{Group: subnetGVK.Group, Resource: "subnets"}
+	tests := []struct {
+		name          string
+		err           error
+		wantForbidden bool
+	}{
+		{
+			name:          "forbidden",
+			err:           apierrors.NewForbidden(resource, "", errors.New("no RBAC policy matched")),
+			wantForbidden: true,
+		},
+		{
+			name: "not found",
+			err:  apierrors.NewNotFound(resource, "subnet"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ForbiddenError("ResourceRefs", subnetGVK, tt.err)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("ForbiddenError() = %v, want it to wrap %v", err, tt.err)
+			}
+			hint := strings.Contains(err.Error(), "grant its ClusterRole get, list and watch on the resources of the ec2.services.k8s.aws API group")
+			if hint != tt.wantForbidden {
+				t.Errorf("ForbiddenError() = %q, mentions the missing access = %v, want %v", err, hint, tt.wantForbidden)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package webhook contains the admission webhooks of the RAM controller.
+// They reject the specs RAM would only reject after the object was accepted,
+// so the mistake is reported to the user applying the object instead of in
+// its ACK.Terminal condition.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtwebhook "github.com/aws-controllers-k8s/runtime/pkg/webhook"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+var (
+	// accountIDRegexp matches an AWS account ID.
+	accountIDRegexp = regexp.MustCompile(`^[0-9]{12}$`)
+	// servicePrincipalRegexp matches the service principals RAM shares
+	// resources with, such as `pca-connector-ad.amazonaws.com`.
+	servicePrincipalRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*\.amazonaws\.com(\.cn)?$`)
+	// organizationResourceRegexp matches the resource of the ARN of an
+	// organization or an organizational unit.
+	organizationResourceRegexp = regexp.MustCompile(`^(organization/o-[a-z0-9]{10,32}|ou/o-[a-z0-9]{10,32}/ou-[a-z0-9]{4,32}-[a-z0-9]{8,32})$`)
+	// iamResourceRegexp matches the resource of the ARN of an IAM role or
+	// user.
+	iamResourceRegexp = regexp.MustCompile(`^(role|user)/.+$`)
+)
+
+// Register registers the validating webhook of ResourceShare within the ACK
+// webhook registry. The ARNs of a resource share must belong to the
+// partition of its region, which is resolved like the controller resolves
+// it and defaults to the supplied region of the controller.
+func Register(region string) error {
+	v := &resourceShareValidator{region: region}
+	return ackrtwebhook.RegisterWebhook(ackrtwebhook.New(
+		"v1alpha1",
+		"ResourceShare",
+		"validating",
+		func(mgr ctrlrt.Manager) error {
+			v.apiReader = mgr.GetAPIReader()
+			return ctrlrt.NewWebhookManagedBy(mgr).
+				For(&svcapitypes.ResourceShare{}).
+				WithValidator(v).
+				Complete()
+		},
+	))
+}
+
+// resourceShareValidator validates the principals, sources, resource ARNs
+// and references of the resource shares.
+type resourceShareValidator struct {
+	// region is the default region of the controller.
+	region string
+	// apiReader reads the namespaces of the resource shares.
+	apiReader client.Reader
+}
+
+var _ admission.CustomValidator = &resourceShareValidator{}
+
+// ValidateCreate validates a created resource share.
+func (v *resourceShareValidator) ValidateCreate(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	ko, ok := obj.(*svcapitypes.ResourceShare)
+	if !ok {
+		return nil, fmt.Errorf("expected a ResourceShare but got %T", obj)
+	}
+	return nil, v.validate(ctx, ko)
+}
+
+// ValidateUpdate validates an updated resource share. The spec is only
+// validated when it changed, so that a resource share accepted before the
+// webhook was installed can still have its finalizer removed. The
+// SelectionRevision the controller maintains is left out of the comparison
+// so that the controller can update it on any resource share.
+func (v *resourceShareValidator) ValidateUpdate(
+	ctx context.Context,
+	oldObj runtime.Object,
+	newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldKo, ok := oldObj.(*svcapitypes.ResourceShare)
+	if !ok {
+		return nil, fmt.Errorf("expected a ResourceShare but got %T", oldObj)
+	}
+	ko, ok := newObj.(*svcapitypes.ResourceShare)
+	if !ok {
+		return nil, fmt.Errorf("expected a ResourceShare but got %T", newObj)
+	}
+	oldSpec := oldKo.Spec.DeepCopy()
+	oldSpec.SelectionRevision = ko.Spec.SelectionRevision
+	if !ko.DeletionTimestamp.IsZero() || apiequality.Semantic.DeepEqual(*oldSpec, ko.Spec) {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, ko)
+}
+
+// ValidateDelete accepts the deletion of any resource share.
+func (v *resourceShareValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an Invalid error listing the problems of the spec of the
+// supplied resource share, or nil.
+func (v *resourceShareValidator) validate(
+	ctx context.Context,
+	ko *svcapitypes.ResourceShare,
+) error {
+	region, err := v.resourceShareRegion(ctx, ko)
+	if err != nil {
+		return err
+	}
+	partition := regionPartition(region)
+	specPath := field.NewPath("spec")
+	errs := validateReferenceFields(ko, specPath)
+
+	allowExternal := ko.Spec.AllowExternalPrincipals == nil || *ko.Spec.AllowExternalPrincipals
+	for i, p := range ko.Spec.Principals {
+		errs = append(errs, validatePrincipal(
+			aws.ToString(p), partition, allowExternal, specPath.Child("principals").Index(i),
+		)...)
+	}
+	for i, s := range ko.Spec.Sources {
+		if !accountIDRegexp.MatchString(aws.ToString(s)) {
+			errs = append(errs, field.Invalid(
+				specPath.Child("sources").Index(i), aws.ToString(s),
+				"must be a 12-digit AWS account ID",
+			))
+		}
+	}
+	for i, a := range ko.Spec.ResourceARNs {
+		path := specPath.Child("resourceARNs").Index(i)
+		parsed, err := arn.Parse(aws.ToString(a))
+		if err != nil || parsed.Service == "" || parsed.Resource == "" {
+			errs = append(errs, field.Invalid(path, aws.ToString(a), "must be a valid ARN"))
+			continue
+		}
+		errs = append(errs, validatePartition(parsed, partition, path)...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		svcapitypes.GroupVersion.WithKind("ResourceShare").GroupKind(),
+		ko.GetName(), errs,
+	)
+}
+
+// resourceShareRegion returns the region of the supplied resource share, in
+// the order the ACK runtime resolves it: the region it was created in, its
+// region annotation, the default region annotation of its namespace, and
+// finally the region of the controller.
+func (v *resourceShareValidator) resourceShareRegion(
+	ctx context.Context,
+	ko *svcapitypes.ResourceShare,
+) (string, error) {
+	if md := ko.Status.ACKResourceMetadata; md != nil && md.Region != nil {
+		return string(*md.Region), nil
+	}
+	if r, ok := ko.GetAnnotations()[ackv1alpha1.AnnotationRegion]; ok && r != "" {
+		return r, nil
+	}
+	if v.apiReader != nil && ko.GetNamespace() != "" {
+		ns := &corev1.Namespace{}
+		err := v.apiReader.Get(ctx, types.NamespacedName{Name: ko.GetNamespace()}, ns)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		if r, ok := ns.GetAnnotations()[ackv1alpha1.AnnotationDefaultRegion]; ok && r != "" {
+			return r, nil
+		}
+	}
+	return v.region, nil
+}
+
+// validateReferenceFields returns an error for each reference field set
+// together with the field it resolves. The controller rejects these specs as
+// well, but only once it reconciles them.
+func validateReferenceFields(
+	ko *svcapitypes.ResourceShare,
+	specPath *field.Path,
+) field.ErrorList {
+	errs := field.ErrorList{}
+	exclusive := func(idField string, idSet bool, refField string, refSet bool) {
+		if idSet && refSet {
+			errs = append(errs, field.Forbidden(
+				specPath.Child(refField),
+				fmt.Sprintf("%s and %s are mutually exclusive", idField, refField),
+			))
+		}
+	}
+	exclusive("permissionARNs", len(ko.Spec.PermissionARNs) > 0, "permissionRefs", len(ko.Spec.PermissionRefs) > 0)
+	exclusive("principals", len(ko.Spec.Principals) > 0, "principalRefs", len(ko.Spec.PrincipalRefs) > 0)
+	exclusive("principals", len(ko.Spec.Principals) > 0, "principalSetRefs", len(ko.Spec.PrincipalSetRefs) > 0)
+	exclusive("resourceARNs", len(ko.Spec.ResourceARNs) > 0, "resourceRefs", len(ko.Spec.ResourceRefs) > 0)
+	exclusive("resourceARNs", len(ko.Spec.ResourceARNs) > 0, "resourceSelectors", len(ko.Spec.ResourceSelectors) > 0)
+	return errs
+}
+
+// validatePrincipal returns the problems of a principal, which is either an
+// AWS account ID, the ARN of an organization, an organizational unit, an IAM
+// role or an IAM user, or a service principal.
+func validatePrincipal(
+	principal string,
+	partition string,
+	allowExternal bool,
+	path *field.Path,
+) field.ErrorList {
+	if accountIDRegexp.MatchString(principal) {
+		return nil
+	}
+	if servicePrincipalRegexp.MatchString(principal) {
+		if !allowExternal {
+			return field.ErrorList{field.Invalid(
+				path, principal,
+				"service principals require allowExternalPrincipals to be true",
+			)}
+		}
+		return nil
+	}
+	parsed, err := arn.Parse(principal)
+	if err != nil {
+		return field.ErrorList{field.Invalid(
+			path, principal,
+			"must be a 12-digit AWS account ID, an ARN or a service principal",
+		)}
+	}
+	switch {
+	case parsed.Service == "organizations" && organizationResourceRegexp.MatchString(parsed.Resource):
+	case parsed.Service == "iam" && iamResourceRegexp.MatchString(parsed.Resource):
+	default:
+		return field.ErrorList{field.Invalid(
+			path, principal,
+			"must be the ARN of an organization, an organizational unit, an IAM role or an IAM user",
+		)}
+	}
+	return validatePartition(parsed, partition, path)
+}
+
+// validatePartition returns an error if the supplied ARN isn't in the
+// supplied partition.
+func validatePartition(
+	parsed arn.ARN,
+	partition string,
+	path *field.Path,
+) field.ErrorList {
+	if parsed.Partition == partition {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(
+		path, parsed.String(),
+		fmt.Sprintf("must be in the %s partition", partition),
+	)}
+}
+
+// regionPartition returns the partition of the supplied region.
+func regionPartition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "us-isob-"):
+		return "aws-iso-b"
+	case strings.HasPrefix(region, "us-isof-"):
+		return "aws-iso-f"
+	case strings.HasPrefix(region, "us-iso-"):
+		return "aws-iso"
+	case strings.HasPrefix(region, "eu-isoe-"):
+		return "aws-iso-e"
+	case strings.HasPrefix(region, "eusc-"):
+		return "aws-eusc"
+	}
+	return "aws"
+}
@@ -0,0 +1,320 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/ram-controller/apis/v1alpha1"
+)
+
+func TestValidatePrincipal(t *testing.T) {
+	tests := []struct {
+		name          string
+		principal     string
+		partition     string
+		allowExternal bool
+		wantErr       bool
+	}{
+		{
+			name:      "account ID",
+			principal: "111122223333",
+			partition: "aws",
+		},
+		{
+			name:      "account ID of the wrong length",
+			principal: "11112222333",
+			partition: "aws",
+			wantErr:   true,
+		},
+		{
+			name:      "organization",
+			principal: "arn:aws:organizations::111122223333:organization/o-exampleorgid",
+			partition: "aws",
+		},
+		{
+			name:      "organizational unit",
+			principal: "arn:aws:organizations::111122223333:ou/o-exampleorgid/ou-abcd-exampleouid",
+			partition: "aws",
+		},
+		{
+			name:      "IAM role",
+			principal: "arn:aws:iam::111122223333:role/example",
+			partition: "aws",
+		},
+		{
+			name:      "IAM user",
+			principal: "arn:aws:iam::111122223333:user/example",
+			partition: "aws",
+		},
+		{
+			name:      "IAM group",
+			principal: "arn:aws:iam::111122223333:group/example",
+			partition: "aws",
+			wantErr:   true,
+		},
+		{
+			name:      "ARN of another service",
+			principal: "arn:aws:s3:::example",
+			partition: "aws",
+			wantErr:   true,
+		},
+		{
+			name:      "ARN of another partition",
+			principal: "arn:aws:iam::111122223333:role/example",
+			partition: "aws-cn",
+			wantErr:   true,
+		},
+		{
+			name:          "service principal with external principals allowed",
+			principal:     "pca-connector-ad.amazonaws.com",
+			partition:     "aws",
+			allowExternal: true,
+		},
+		{
+			name:      "service principal with external principals disallowed",
+			principal: "pca-connector-ad.amazonaws.com",
+			partition: "aws",
+			wantErr:   true,
+		},
+		{
+			name:      "neither an account ID, an ARN nor a service principal",
+			principal: "example",
+			partition: "aws",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validatePrincipal(tt.principal, tt.partition, tt.allowExternal, field.NewPath("spec", "principals").Index(0))
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validatePrincipal(%s) = %v, wantErr %v", tt.principal, errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegionPartition(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{region: "us-west-2", want: "aws"},
+		{region: "cn-north-1", want: "aws-cn"},
+		{region: "us-gov-west-1", want: "aws-us-gov"},
+		{region: "us-iso-east-1", want: "aws-iso"},
+		{region: "us-isob-east-1", want: "aws-iso-b"},
+		{region: "us-isof-south-1", want: "aws-iso-f"},
+		{region: "eu-isoe-west-1", want: "aws-iso-e"},
+		{region: "eusc-de-east-1", want: "aws-eusc"},
+		{region: "", want: "aws"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			if got := regionPartition(tt.region); got != tt.want {
+				t.Errorf("regionPartition(%s) = %s, want %s", tt.region, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceShareRegion(t *testing.T) {
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:        "china",
+			Annotations: map[string]string{ackv1alpha1.AnnotationDefaultRegion: "cn-north-1"},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+	}
+	builder := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme)
+	for _, ns := range namespaces {
+		builder = builder.WithObjects(ns)
+	}
+	v := &resourceShareValidator{region: "us-west-2", apiReader: builder.Build()}
+
+	createdIn := ackv1alpha1.AWSRegion("us-gov-west-1")
+	tests := []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		metadata    *ackv1alpha1.ResourceMetadata
+		want        string
+	}{
+		{
+			name:      "region the resource share was created in",
+			namespace: "china",
+			annotations: map[string]string{
+				ackv1alpha1.AnnotationRegion: "eu-west-1",
+			},
+			metadata: &ackv1alpha1.ResourceMetadata{Region: &createdIn},
+			want:     "us-gov-west-1",
+		},
+		{
+			name:      "region annotation",
+			namespace: "china",
+			annotations: map[string]string{
+				ackv1alpha1.AnnotationRegion: "eu-west-1",
+			},
+			want: "eu-west-1",
+		},
+		{
+			name:      "default region of the namespace",
+			namespace: "china",
+			want:      "cn-north-1",
+		},
+		{
+			name:      "namespace without a default region",
+			namespace: "plain",
+			want:      "us-west-2",
+		},
+		{
+			name:      "namespace not found",
+			namespace: "missing",
+			want:      "us-west-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "share",
+					Namespace:   tt.namespace,
+					Annotations: tt.annotations,
+				},
+				Status: svcapitypes.ResourceShareStatus{ACKResourceMetadata: tt.metadata},
+			}
+			got, err := v.resourceShareRegion(context.Background(), ko)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("resourceShareRegion() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	v := &resourceShareValidator{region: "us-west-2"}
+	tests := []struct {
+		name    string
+		spec    svcapitypes.ResourceShareSpec
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			spec: svcapitypes.ResourceShareSpec{
+				Principals:   aws.StringSlice([]string{"111122223333"}),
+				Sources:      aws.StringSlice([]string{"444455556666"}),
+				ResourceARNs: aws.StringSlice([]string{"arn:aws:ec2:us-west-2:111122223333:subnet/subnet-1"}),
+			},
+		},
+		{
+			name: "invalid source",
+			spec: svcapitypes.ResourceShareSpec{
+				Sources: aws.StringSlice([]string{"arn:aws:iam::111122223333:role/example"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource ARN of another partition",
+			spec: svcapitypes.ResourceShareSpec{
+				ResourceARNs: aws.StringSlice([]string{"arn:aws-cn:ec2:cn-north-1:111122223333:subnet/subnet-1"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid resource ARN",
+			spec: svcapitypes.ResourceShareSpec{
+				ResourceARNs: aws.StringSlice([]string{"subnet-1"}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource ARNs and resource selectors",
+			spec: svcapitypes.ResourceShareSpec{
+				ResourceARNs: aws.StringSlice([]string{"arn:aws:ec2:us-west-2:111122223333:subnet/subnet-1"}),
+				ResourceSelectors: []*svcapitypes.ResourceSelector{{
+					Group: aws.String("ec2.services.k8s.aws"),
+					Kind:  aws.String("Subnet"),
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := &svcapitypes.ResourceShare{
+				ObjectMeta: metav1.ObjectMeta{Name: "share"},
+				Spec:       tt.spec,
+			}
+			if err := v.validate(context.Background(), ko); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	v := &resourceShareValidator{region: "us-west-2"}
+	invalid := svcapitypes.ResourceShareSpec{
+		Name:    aws.String("share"),
+		Sources: aws.StringSlice([]string{"not-an-account"}),
+	}
+	tests := []struct {
+		name    string
+		update  func(spec *svcapitypes.ResourceShareSpec)
+		wantErr bool
+	}{
+		{
+			name:   "unchanged spec isn't validated",
+			update: func(spec *svcapitypes.ResourceShareSpec) {},
+		},
+		{
+			name: "selection revision updated by the controller isn't validated",
+			update: func(spec *svcapitypes.ResourceShareSpec) {
+				spec.SelectionRevision = aws.String("revision")
+			},
+		},
+		{
+			name: "changed spec is validated",
+			update: func(spec *svcapitypes.ResourceShareSpec) {
+				spec.Name = aws.String("renamed")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldKo := &svcapitypes.ResourceShare{
+				ObjectMeta: metav1.ObjectMeta{Name: "share"},
+				Spec:       *invalid.DeepCopy(),
+			}
+			ko := oldKo.DeepCopy()
+			tt.update(&ko.Spec)
+			if _, err := v.ValidateUpdate(context.Background(), oldKo, ko); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+	if input.PolicyTemplate, err = policyTemplate(desired); err != nil {
+		return nil, err
+	}
+	input.ClientToken = clientToken(desired, "CreatePermission")
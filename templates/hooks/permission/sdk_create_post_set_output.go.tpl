@@ -0,0 +1 @@
+	bumpClientTokenNonce(ko)
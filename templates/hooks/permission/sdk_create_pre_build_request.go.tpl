@@ -0,0 +1,3 @@
+	if err = validatePolicy(desired); err != nil {
+		return nil, err
+	}
@@ -1,3 +1,8 @@
-if resp.Permission.Permission != nil {
-  ko.Spec.PolicyTemplate = resp.Permission.Permission
+setPolicyTemplate(ko, resp.Permission.Permission)
+if err = rm.setPermissionVersions(ctx, r, ko); err != nil {
+  return nil, err
 }
+if err = rm.setReplacePermissionAssociationsWork(ctx, ko); err != nil {
+  return nil, err
+}
+setPermissionReadiness(r, ko)
@@ -0,0 +1 @@
+	input.ClientToken = clientToken(desired, "CreateResourceShare")
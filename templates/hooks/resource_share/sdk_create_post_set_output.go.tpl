@@ -0,0 +1,2 @@
+	setManagedAssociations(ko)
+	bumpClientTokenNonce(ko)
@@ -0,0 +1,6 @@
+	if err = validateAssociationMode(desired); err != nil {
+		return nil, err
+	}
+	if err = rm.setDefaultPermissions(ctx, desired); err != nil {
+		return nil, err
+	}
@@ -2,4 +2,9 @@
 		input.ResourceOwner = svcsdktypes.ResourceOwnerSelf
 	} else {
 		input.ResourceOwner = svcsdktypes.ResourceOwnerOtherAccounts
-	}
\ No newline at end of file
+	}
+	input.MaxResults = listPageSize()
+	if arn := resourceShareARN(r.ko); arn != "" {
+		input.Name = nil
+		input.ResourceShareArns = []string{arn}
+	}
@@ -5,3 +5,9 @@
 	if err = rm.getResourceShareAssociations(ctx, &resource{ko}); err != nil {
 		return nil, err
 	}
+	if err = resourceShareFailed(ko); err != nil && !r.IsBeingDeleted() {
+		return &resource{ko}, err
+	}
+	if err = promotionInProgress(ko); err != nil && !r.IsBeingDeleted() {
+		return &resource{ko}, err
+	}
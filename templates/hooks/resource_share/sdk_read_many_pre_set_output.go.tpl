@@ -0,0 +1,6 @@
+	if err = rm.getRemainingResourceShares(ctx, input, resp); err != nil {
+		return nil, err
+	}
+	if resp.ResourceShares, err = selectResourceShares(r.ko, resp.ResourceShares); err != nil {
+		return nil, err
+	}
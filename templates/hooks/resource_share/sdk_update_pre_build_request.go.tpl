@@ -1,3 +1,12 @@
+	if err := validateAssociationMode(desired); err != nil {
+		return nil, err
+	}
+	// RAM only updates STANDARD resource shares, the other changes are still
+	// different once the promotion is done and made by a later reconcile.
+	if delta.DifferentAt("Spec.PromoteCreatedFromPolicy") {
+		return rm.promoteCreatedFromPolicy(ctx, desired, latest)
+	}
+
 	if delta.DifferentAt("Spec.Tags") {
 		if err := rm.syncTags(ctx, desired, latest); err != nil {
 			return nil, err
@@ -10,12 +19,39 @@
 		}
 	}
 
+	// Resolved after syncing the permissions, RAM attaches the default
+	// permissions along with the resources of the new types.
+	if delta.DifferentAt("Spec.ResourceARNs") {
+		if err := rm.addDefaultPermissions(ctx, desired, latest); err != nil {
+			return nil, err
+		}
+	}
+
 	if delta.DifferentAt("Spec.ResourceARNs") || delta.DifferentAt("Spec.Principals") || delta.DifferentAt("Spec.Sources") {
 		if err := rm.syncResourceShareResources(ctx, desired, latest); err != nil {
-			return nil, err
+			// desired carries the OrganizationSharingEnabled condition,
+			// which explains why RAM refused an organization principal.
+			return desired, err
 		}
 	}
 
-	if !delta.DifferentExcept("Spec.Tags", "Spec.PermissionARNs", "Spec.ResourceARNs", "Spec.Principals", "Spec.Sources") {
+	if err := rm.refreshAssociationsStatus(ctx, desired, resourceShareARN(latest.ko)); err != nil {
+		return nil, err
+	}
+	bumpClientTokenNonce(desired.ko)
+
+	if !delta.DifferentExcept(
+		"Spec.Tags",
+		"Spec.PermissionARNs",
+		"Spec.ResourceARNs",
+		"Spec.Principals",
+		"Spec.Sources",
+		"Spec.AssociationMode",
+		"Spec.ResourceRefs",
+		"Spec.ResourceSelectors",
+		"Spec.PrincipalRefs",
+		"Spec.PrincipalSetRefs",
+		"Spec.PromoteCreatedFromPolicy",
+	) {
 		return desired, nil
 	}
@@ -0,0 +1,2 @@
+	input.Principals, input.ResourceArns = associatedEntities(desired.ko)
+	input.ClientToken = clientToken(desired.ko, "AssociateResourceShare")
@@ -0,0 +1,4 @@
+	if associations := filterAssociations(desired, resp.ResourceShareAssociations); len(associations) > 0 {
+		setResourceShareAssociation(ko, &associations[0])
+	}
+	bumpClientTokenNonce(ko)
@@ -0,0 +1,3 @@
+	if err = validateAssociatedEntity(desired); err != nil {
+		return nil, err
+	}
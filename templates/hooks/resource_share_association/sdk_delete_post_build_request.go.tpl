@@ -0,0 +1,2 @@
+	input.Principals, input.ResourceArns = associatedEntities(r.ko)
+	input.ClientToken = clientToken(r.ko, "DisassociateResourceShare")
@@ -0,0 +1,3 @@
+	input.AssociationType = associationType(r.ko)
+	input.ResourceShareArns = []string{*r.ko.Spec.ResourceShareARN}
+	input.MaxResults = listPageSize()
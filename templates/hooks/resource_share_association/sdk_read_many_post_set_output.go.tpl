@@ -0,0 +1,3 @@
+	if err = associationFailed(ko); err != nil && !r.IsBeingDeleted() {
+		return &resource{ko}, err
+	}
@@ -0,0 +1,3 @@
+	if err = validateAssociatedEntity(r); err != nil {
+		return nil, err
+	}
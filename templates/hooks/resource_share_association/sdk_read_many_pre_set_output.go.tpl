@@ -0,0 +1,4 @@
+	if err = rm.getRemainingResourceShareAssociations(ctx, input, resp); err != nil {
+		return nil, err
+	}
+	resp.ResourceShareAssociations = filterAssociations(r, resp.ResourceShareAssociations)
@@ -0,0 +1,7 @@
+	input.ResourceShareInvitationArn, err = rm.getPendingInvitationARN(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	if actionIsReject(desired) {
+		return rm.rejectInvitation(ctx, desired, input.ResourceShareInvitationArn)
+	}
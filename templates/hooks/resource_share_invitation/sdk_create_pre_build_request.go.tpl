@@ -0,0 +1,3 @@
+	if err = validateAction(desired); err != nil {
+		return nil, err
+	}
@@ -0,0 +1,2 @@
+	input.ResourceShareArns = []string{*r.ko.Spec.ResourceShareARN}
+	input.MaxResults = listPageSize()
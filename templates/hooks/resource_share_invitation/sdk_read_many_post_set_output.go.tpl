@@ -0,0 +1,8 @@
+	if invitationPending(ko) {
+		// The invitation has not been responded to yet. Report it as not
+		// found so that the reconciler responds to it through sdkCreate.
+		return nil, ackerr.NotFound
+	}
+	if err = setObservedAction(ko); err != nil && !r.IsBeingDeleted() {
+		return &resource{ko}, err
+	}
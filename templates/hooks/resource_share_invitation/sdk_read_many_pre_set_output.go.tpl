@@ -0,0 +1,4 @@
+	if err = rm.getRemainingResourceShareInvitations(ctx, input, resp); err != nil {
+		return nil, err
+	}
+	resp.ResourceShareInvitations = filterInvitations(r, resp.ResourceShareInvitations)
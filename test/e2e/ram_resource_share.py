@@ -136,4 +136,102 @@ def list_associated_resources(arn):
         if 'resourceShareAssociations' in resp and len(resp['resourceShareAssociations']) > 0:
             return resp['resourceShareAssociations'][0]
     except c.UnknownResourceException:
-        return None
\ No newline at end of file
+        return None
+
+def get_resource_share_invitation(resource_share_arn):
+    """Returns the most recent invitation to the supplied ResourceShare arn.
+
+    If there is no such invitation, returns None
+    """
+    c = boto3.client('ram')
+    resp = c.get_resource_share_invitations(
+        resourceShareArns=[resource_share_arn],
+    )
+    invitations = sorted(
+        resp['resourceShareInvitations'],
+        key=lambda inv: inv['invitationTimestamp'],
+        reverse=True,
+    )
+    if len(invitations) > 0:
+        return invitations[0]
+    return None
+
+def get_resource_share_associations(arn, association_type):
+    """Returns the associations of the supplied type, RESOURCE or PRINCIPAL,
+    that are not disassociated for the supplied ResourceShare arn.
+    """
+    c = boto3.client('ram')
+    resp = c.get_resource_share_associations(
+        associationType=association_type,
+        resourceShareArns=[arn],
+    )
+    return [
+        a for a in resp['resourceShareAssociations']
+        if a['status'] not in ("DISASSOCIATED", "DISASSOCIATING")
+    ]
+
+def wait_until_associated(
+        arn: str,
+        association_type: str,
+        entity: str,
+        associated: bool = True,
+        timeout_seconds: int = DEFAULT_WAIT_UNTIL_EXISTS_TIMEOUT_SECONDS,
+        interval_seconds: int = DEFAULT_WAIT_UNTIL_EXISTS_INTERVAL_SECONDS,
+    ) -> None:
+    """Waits until the supplied entity is associated with, or when associated
+    is False disassociated from, the ResourceShare with the supplied arn.
+
+    Raises:
+        pytest.fail upon timeout
+    """
+    now = datetime.datetime.now()
+    timeout = now + datetime.timedelta(seconds=timeout_seconds)
+
+    while True:
+        if datetime.datetime.now() >= timeout:
+            pytest.fail(
+                f"Timed out waiting for {entity} to be "
+                f"{'associated with' if associated else 'disassociated from'} "
+                "the ResourceShare in RAM API"
+            )
+        time.sleep(interval_seconds)
+
+        entities = [
+            a['associatedEntity']
+            for a in get_resource_share_associations(arn, association_type)
+            if a['status'] == "ASSOCIATED"
+        ]
+        if (entity in entities) == associated:
+            break
+
+def wait_until_resource_shared(
+        resource_arn: str,
+        timeout_seconds: int = DEFAULT_WAIT_UNTIL_EXISTS_TIMEOUT_SECONDS,
+        interval_seconds: int = DEFAULT_WAIT_UNTIL_EXISTS_INTERVAL_SECONDS,
+    ) -> dict:
+    """Waits until the resource with the supplied ARN is associated with a
+    ResourceShare, as RAM does once a resource policy is attached to it, and
+    returns the association.
+
+    Raises:
+        pytest.fail upon timeout
+    """
+    c = boto3.client('ram')
+    now = datetime.datetime.now()
+    timeout = now + datetime.timedelta(seconds=timeout_seconds)
+
+    while True:
+        if datetime.datetime.now() >= timeout:
+            pytest.fail(
+                "Timed out waiting for the resource to be "
+                "shared in RAM API"
+            )
+        time.sleep(interval_seconds)
+
+        resp = c.get_resource_share_associations(
+            associationType='RESOURCE',
+            resourceArn=resource_arn,
+        )
+        for a in resp['resourceShareAssociations']:
+            if a['status'] == "ASSOCIATED":
+                return a
@@ -0,0 +1,126 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the ResourceShareAssociation API.
+"""
+
+import pytest
+import time
+import logging
+
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_ram_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+from e2e.bootstrap_resources import get_bootstrap_resources
+from e2e import ram_resource_share
+
+RESOURCE_PLURAL = "resourceshareassociations"
+
+CREATE_WAIT_AFTER_SECONDS = 5
+MODIFY_WAIT_AFTER_SECONDS = 20
+DELETE_WAIT_AFTER_SECONDS = 20
+
+
+@pytest.fixture(scope="module")
+def resource_share():
+    resource_name = random_suffix_name("resource-share", 24)
+
+    replacements = REPLACEMENT_VALUES.copy()
+    replacements["RESOURCE_SHARE_NAME"] = resource_name
+
+    resource_data = load_ram_resource(
+        "ram_resource_share",
+        additional_replacements=replacements,
+    )
+    logging.debug(resource_data)
+
+    ref = k8s.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, "resourceshares",
+        resource_name, namespace="default",
+    )
+    k8s.create_custom_resource(ref, resource_data)
+    k8s.wait_resource_consumed_by_controller(ref)
+    ram_resource_share.wait_until_exists(resource_name)
+    assert k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+    yield resource_name, ref
+
+    _, deleted = k8s.delete_custom_resource(
+        ref,
+        period_length=DELETE_WAIT_AFTER_SECONDS,
+    )
+    assert deleted
+
+
+@service_marker
+class TestResourceShareAssociation:
+    def test_association(self, resource_share):
+        resource_share_name, resource_share_ref = resource_share
+
+        subnet = get_bootstrap_resources().RamVPC.public_subnets
+        subnet_arn = f"arn:aws:ec2:{subnet.region}:{subnet.account_id}:subnet/{subnet.subnet_ids[0]}"
+
+        resource_name = random_suffix_name("association", 24)
+        replacements = REPLACEMENT_VALUES.copy()
+        replacements["ASSOCIATION_NAME"] = resource_name
+        replacements["RESOURCE_SHARE_NAME"] = resource_share_name
+        replacements["RESOURCE_ARN"] = subnet_arn
+
+        resource_data = load_ram_resource(
+            "ram_share_association",
+            additional_replacements=replacements,
+        )
+        logging.debug(resource_data)
+
+        ref = k8s.CustomResourceReference(
+            CRD_GROUP, CRD_VERSION, RESOURCE_PLURAL,
+            resource_name, namespace="default",
+        )
+        k8s.create_custom_resource(ref, resource_data)
+        k8s.wait_resource_consumed_by_controller(ref)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+        assert k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(ref)
+        assert cr['status']['status'] == "ASSOCIATED"
+        share = k8s.get_resource(resource_share_ref)
+        resource_share_arn = share['status']['ackResourceMetadata']['arn']
+
+        ram_resource_share.wait_until_associated(resource_share_arn, "RESOURCE", subnet_arn)
+
+        # The resource share records the resource its association owns...
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        share = k8s.get_resource(resource_share_ref)
+        assert subnet_arn in share['status'].get('resourceARNsFromAssociations', [])
+
+        # ...and doesn't disassociate it, even when it manages its resources
+        # with an empty list.
+        k8s.patch_custom_resource(resource_share_ref, {"spec": {"resourceARNs": []}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        assert k8s.wait_on_condition(resource_share_ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        associations = ram_resource_share.get_resource_share_associations(resource_share_arn, "RESOURCE")
+        assert subnet_arn in [a['associatedEntity'] for a in associations]
+
+        # Deleting the association disassociates the resource.
+        _, deleted = k8s.delete_custom_resource(
+            ref,
+            period_length=DELETE_WAIT_AFTER_SECONDS,
+        )
+        assert deleted
+
+        ram_resource_share.wait_until_associated(
+            resource_share_arn, "RESOURCE", subnet_arn, associated=False,
+        )
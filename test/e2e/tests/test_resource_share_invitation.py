@@ -0,0 +1,154 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the ResourceShareInvitation API.
+"""
+
+import os
+import pytest
+import time
+import logging
+import boto3
+
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_ram_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+from e2e import ram_resource_share
+
+RESOURCE_PLURAL = "resourceshareinvitations"
+
+CREATE_WAIT_AFTER_SECONDS = 10
+MODIFY_WAIT_AFTER_SECONDS = 20
+DELETE_WAIT_AFTER_SECONDS = 20
+
+# Sending an invitation to the account the controller runs in takes a second
+# account outside of its organization. The tests that need one are skipped
+# unless the name of an AWS CLI profile of that account is supplied.
+SENDER_PROFILE = os.environ.get("RAM_E2E_SENDER_PROFILE")
+
+
+def create_invitation(resource_share_arn, sender_account_id, action):
+    resource_name = random_suffix_name("invitation", 24)
+
+    replacements = REPLACEMENT_VALUES.copy()
+    replacements["INVITATION_NAME"] = resource_name
+    replacements["RESOURCE_SHARE_ARN"] = resource_share_arn
+    replacements["SENDER_ACCOUNT_ID"] = sender_account_id
+    replacements["ACTION"] = action
+
+    resource_data = load_ram_resource(
+        "ram_resource_share_invitation",
+        additional_replacements=replacements,
+    )
+    logging.debug(resource_data)
+
+    ref = k8s.CustomResourceReference(
+        CRD_GROUP, CRD_VERSION, RESOURCE_PLURAL,
+        resource_name, namespace="default",
+    )
+    k8s.create_custom_resource(ref, resource_data)
+    k8s.wait_resource_consumed_by_controller(ref)
+    return ref
+
+
+@pytest.fixture(scope="module")
+def sender_resource_share():
+    if SENDER_PROFILE is None:
+        pytest.skip("RAM_E2E_SENDER_PROFILE isn't set")
+
+    receiver_account_id = boto3.client('sts').get_caller_identity()['Account']
+    sender = boto3.Session(profile_name=SENDER_PROFILE)
+    sender_account_id = sender.client('sts').get_caller_identity()['Account']
+    c = sender.client('ram')
+
+    shares = []
+    for _ in range(2):
+        resp = c.create_resource_share(
+            name=random_suffix_name("invited-share", 24),
+            principals=[receiver_account_id],
+            allowExternalPrincipals=True,
+        )
+        shares.append(resp['resourceShare']['resourceShareArn'])
+
+    yield sender_account_id, shares
+
+    for arn in shares:
+        c.delete_resource_share(resourceShareArn=arn)
+
+
+@service_marker
+class TestResourceShareInvitation:
+    def test_waits_for_invitation(self):
+        sts = boto3.client('sts').get_caller_identity()
+        partition = sts['Arn'].split(":")[1]
+        region = boto3.session.Session().region_name
+        resource_share_arn = (
+            f"arn:{partition}:ram:{region}:111122223333:"
+            f"resource-share/{random_suffix_name('missing', 24)}"
+        )
+
+        ref = create_invitation(resource_share_arn, "111122223333", "ACCEPT")
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+        # Without a pending invitation the resource waits for one instead of
+        # failing.
+        cr = k8s.get_resource(ref)
+        assert cr is not None
+        assert cr.get('status', {}).get('status') is None
+        terminal = k8s.get_resource_condition(ref, "ACK.Terminal")
+        assert terminal is None or terminal['status'] != "True"
+        synced = k8s.get_resource_condition(ref, "ACK.ResourceSynced")
+        assert synced is None or synced['status'] != "True"
+
+        _, deleted = k8s.delete_custom_resource(
+            ref,
+            period_length=DELETE_WAIT_AFTER_SECONDS,
+        )
+        assert deleted
+
+    def test_accept_and_reject(self, sender_resource_share):
+        sender_account_id, (accepted_arn, rejected_arn) = sender_resource_share
+
+        accept_ref = create_invitation(accepted_arn, sender_account_id, "ACCEPT")
+        reject_ref = create_invitation(rejected_arn, sender_account_id, "REJECT")
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+        assert k8s.wait_on_condition(accept_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+        assert k8s.wait_on_condition(reject_ref, "ACK.ResourceSynced", "True", wait_periods=10)
+
+        cr = k8s.get_resource(accept_ref)
+        assert cr['status']['status'] == "ACCEPTED"
+        cr = k8s.get_resource(reject_ref)
+        assert cr['status']['status'] == "REJECTED"
+
+        latest = ram_resource_share.get_resource_share_invitation(accepted_arn)
+        assert latest is not None
+        assert latest['status'] == "ACCEPTED"
+        latest = ram_resource_share.get_resource_share_invitation(rejected_arn)
+        assert latest is not None
+        assert latest['status'] == "REJECTED"
+
+        # A response can't be changed once RAM recorded it: the controller
+        # reports the observed action instead of flipping it.
+        k8s.patch_custom_resource(accept_ref, {"spec": {"action": "REJECT"}})
+        time.sleep(MODIFY_WAIT_AFTER_SECONDS)
+        latest = ram_resource_share.get_resource_share_invitation(accepted_arn)
+        assert latest['status'] == "ACCEPTED"
+
+        for ref in (accept_ref, reject_ref):
+            _, deleted = k8s.delete_custom_resource(
+                ref,
+                period_length=DELETE_WAIT_AFTER_SECONDS,
+            )
+            assert deleted
@@ -0,0 +1,137 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the promotion of CREATED_FROM_POLICY resource shares.
+"""
+
+import json
+import os
+import pytest
+import time
+import logging
+import boto3
+
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_ram_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+from e2e import ram_resource_share
+
+RESOURCE_PLURAL = "resourceshares"
+
+CREATE_WAIT_AFTER_SECONDS = 10
+DELETE_WAIT_AFTER_SECONDS = 20
+
+# RAM only creates a resource share from a resource policy that grants
+# access to another account. The test is skipped unless one is supplied.
+PRINCIPAL_ACCOUNT_ID = os.environ.get("RAM_E2E_PRINCIPAL_ACCOUNT_ID")
+
+
+@pytest.fixture(scope="module")
+def created_from_policy_share():
+    if PRINCIPAL_ACCOUNT_ID is None:
+        pytest.skip("RAM_E2E_PRINCIPAL_ACCOUNT_ID isn't set")
+
+    c = boto3.client('route53resolver')
+    rule_group = c.create_firewall_rule_group(
+        CreatorRequestId=random_suffix_name("ram-promotion", 32),
+        Name=random_suffix_name("ram-promotion", 32),
+    )['FirewallRuleGroup']
+    rule_group_arn = rule_group['Arn']
+
+    c.put_firewall_rule_group_policy(
+        Arn=rule_group_arn,
+        FirewallRuleGroupPolicy=json.dumps({
+            "Version": "2012-10-17",
+            "Statement": [{
+                "Effect": "Allow",
+                "Principal": {"AWS": PRINCIPAL_ACCOUNT_ID},
+                "Action": [
+                    "route53resolver:GetFirewallRuleGroup",
+                    "route53resolver:ListFirewallRuleGroups",
+                ],
+                "Resource": rule_group_arn,
+            }],
+        }),
+    )
+
+    association = ram_resource_share.wait_until_resource_shared(rule_group_arn)
+
+    yield association['resourceShareArn'], association['resourceShareName']
+
+    # The test deleted the promoted resource share along with the
+    # ResourceShare resource.
+    c.delete_firewall_rule_group(FirewallRuleGroupId=rule_group['Id'])
+
+
+@service_marker
+class TestResourceSharePromotion:
+    def test_promotion(self, created_from_policy_share):
+        resource_share_arn, resource_share_name = created_from_policy_share
+
+        latest = ram_resource_share.get_resource_shares(resource_share_name)
+        assert latest is not None
+        assert latest['featureSet'] == "CREATED_FROM_POLICY"
+
+        resource_name = random_suffix_name("promoted-share", 24)
+        replacements = REPLACEMENT_VALUES.copy()
+        replacements["RESOURCE_NAME"] = resource_name
+        replacements["RESOURCE_SHARE_NAME"] = resource_share_name
+        replacements["TAG_KEY"] = "promoted"
+        replacements["TAG_VALUE"] = resource_name
+
+        resource_data = load_ram_resource(
+            "ram_resource_share_promotion",
+            additional_replacements=replacements,
+        )
+        logging.debug(resource_data)
+
+        ref = k8s.CustomResourceReference(
+            CRD_GROUP, CRD_VERSION, RESOURCE_PLURAL,
+            resource_name, namespace="default",
+        )
+        k8s.create_custom_resource(ref, resource_data)
+        k8s.wait_resource_consumed_by_controller(ref)
+        time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+        # The resource share goes through PROMOTING_TO_STANDARD and is only
+        # synced once it is STANDARD.
+        assert k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=20)
+
+        cr = k8s.get_resource(ref)
+        assert cr['status']['ackResourceMetadata']['arn'] == resource_share_arn
+        assert cr['status']['featureSet'] == "STANDARD"
+
+        latest = ram_resource_share.get_resource_shares(resource_share_name)
+        assert latest['featureSet'] == "STANDARD"
+
+        # The tags requested along with the promotion are only added once the
+        # resource share is STANDARD, before it is reported as synced.
+        tags = {t['key']: t['value'] for t in latest.get('tags', [])}
+        assert tags.get("promoted") == resource_name
+
+        # Its CREATED_FROM_POLICY permission was promoted to a customer
+        # managed permission associated with the resource share.
+        promoted = cr['status'].get('promotedPermissionARNs', [])
+        assert len(promoted) > 0
+        associated = [
+            p['arn'] for p in ram_resource_share.list_associated_permissions(arn=resource_share_arn)
+        ]
+        for arn in promoted:
+            assert arn in associated
+
+        _, deleted = k8s.delete_custom_resource(
+            ref,
+            period_length=DELETE_WAIT_AFTER_SECONDS,
+        )
+        assert deleted
@@ -0,0 +1,176 @@
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License"). You may
+# not use this file except in compliance with the License. A copy of the
+# License is located at
+#
+# 	 http://aws.amazon.com/apache2.0/
+#
+# or in the "license" file accompanying this file. This file is distributed
+# on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+# express or implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+"""Integration tests for the resource selectors of the ResourceShare API.
+"""
+
+import pytest
+import time
+import logging
+
+from kubernetes import client
+from kubernetes.client.rest import ApiException
+from acktest.resources import random_suffix_name
+from acktest.k8s import resource as k8s
+from e2e import service_marker, CRD_GROUP, CRD_VERSION, load_ram_resource
+from e2e.replacement_values import REPLACEMENT_VALUES
+from e2e.bootstrap_resources import get_bootstrap_resources
+from e2e import ram_resource_share
+
+RESOURCE_PLURAL = "resourceshares"
+
+SUBNET_GROUP = "ec2.services.k8s.aws"
+SUBNET_VERSION = "v1alpha1"
+SUBNET_PLURAL = "subnets"
+SUBNET_CRD_NAME = f"{SUBNET_PLURAL}.{SUBNET_GROUP}"
+
+CREATE_WAIT_AFTER_SECONDS = 5
+MODIFY_WAIT_AFTER_SECONDS = 20
+DELETE_WAIT_AFTER_SECONDS = 20
+
+
+@pytest.fixture(scope="module")
+def subnet_crd(k8s_client):
+    """Installs a minimal Subnet CRD standing in for the one of the EC2
+    controller, which isn't installed by the RAM integration tests. Its
+    objects carry the status the EC2 controller would set, without a status
+    subresource so the tests can set it.
+    """
+    api = client.ApiextensionsV1Api(k8s_client)
+    try:
+        api.read_custom_resource_definition(SUBNET_CRD_NAME)
+        pytest.skip(f"{SUBNET_CRD_NAME} is already installed, most likely by the EC2 controller")
+    except ApiException as e:
+        if e.status != 404:
+            raise
+
+    crd = client.V1CustomResourceDefinition(
+        metadata=client.V1ObjectMeta(name=SUBNET_CRD_NAME),
+        spec=client.V1CustomResourceDefinitionSpec(
+            group=SUBNET_GROUP,
+            names=client.V1CustomResourceDefinitionNames(
+                kind="Subnet", list_kind="SubnetList",
+                plural=SUBNET_PLURAL, singular="subnet",
+            ),
+            scope="Namespaced",
+            versions=[client.V1CustomResourceDefinitionVersion(
+                name=SUBNET_VERSION, served=True, storage=True,
+                schema=client.V1CustomResourceValidation(
+                    open_apiv3_schema=client.V1JSONSchemaProps(
+                        type="object",
+                        x_kubernetes_preserve_unknown_fields=True,
+                    ),
+                ),
+            )],
+        ),
+    )
+    api.create_custom_resource_definition(crd)
+    # Give the API server the time to serve the new kind.
+    time.sleep(CREATE_WAIT_AFTER_SECONDS)
+
+    yield
+
+    api.delete_custom_resource_definition(SUBNET_CRD_NAME)
+
+
+def create_subnet(subnet_id, arn, labels):
+    """Creates a synced Subnet with the supplied ARN and labels."""
+    resource_name = random_suffix_name("subnet", 24)
+    ref = k8s.CustomResourceReference(
+        SUBNET_GROUP, SUBNET_VERSION, SUBNET_PLURAL,
+        resource_name, namespace="default",
+    )
+    k8s.create_custom_resource(ref, {
+        "apiVersion": f"{SUBNET_GROUP}/{SUBNET_VERSION}",
+        "kind": "Subnet",
+        "metadata": {
+            "name": resource_name,
+            "namespace": "default",
+            "labels": labels,
+        },
+        "spec": {"subnetID": subnet_id},
+        "status": {
+            "ackResourceMetadata": {"arn": arn},
+            "conditions": [{"type": "ACK.ResourceSynced", "status": "True"}],
+        },
+    })
+    return ref
+
+
+@service_marker
+class TestResourceShareSelector:
+    def test_selector(self, subnet_crd):
+        subnets = get_bootstrap_resources().RamVPC.public_subnets
+        arns = [
+            f"arn:aws:ec2:{subnets.region}:{subnets.account_id}:subnet/{subnet_id}"
+            for subnet_id in subnets.subnet_ids[:2]
+        ]
+        label = random_suffix_name("workloads", 24)
+
+        selected = create_subnet(subnets.subnet_ids[0], arns[0], {"share": label})
+        unselected = create_subnet(subnets.subnet_ids[1], arns[1], {"share": "other"})
+
+        resource_name = random_suffix_name("resource-share", 24)
+        replacements = REPLACEMENT_VALUES.copy()
+        replacements["RESOURCE_SHARE_NAME"] = resource_name
+        replacements["SHARE_LABEL"] = label
+
+        resource_data = load_ram_resource(
+            "ram_resource_share_selector",
+            additional_replacements=replacements,
+        )
+        logging.debug(resource_data)
+
+        ref = k8s.CustomResourceReference(
+            CRD_GROUP, CRD_VERSION, RESOURCE_PLURAL,
+            resource_name, namespace="default",
+        )
+        k8s.create_custom_resource(ref, resource_data)
+        k8s.wait_resource_consumed_by_controller(ref)
+        ram_resource_share.wait_until_exists(resource_name)
+        assert k8s.wait_on_condition(ref, "ACK.ResourceSynced", "True", wait_periods=5)
+
+        cr = k8s.get_resource(ref)
+        resource_share_arn = cr['status']['ackResourceMetadata']['arn']
+
+        # Only the Subnet with the label is associated.
+        ram_resource_share.wait_until_associated(resource_share_arn, "RESOURCE", arns[0])
+        associations = ram_resource_share.get_resource_share_associations(resource_share_arn, "RESOURCE")
+        assert arns[1] not in [a['associatedEntity'] for a in associations]
+
+        # Labelling the other Subnet associates it without touching the
+        # resource share.
+        k8s.patch_custom_resource(unselected, {"metadata": {"labels": {"share": label}}})
+        ram_resource_share.wait_until_associated(resource_share_arn, "RESOURCE", arns[1])
+
+        # Deleting a selected Subnet disassociates it.
+        _, deleted = k8s.delete_custom_resource(
+            selected,
+            period_length=DELETE_WAIT_AFTER_SECONDS,
+        )
+        assert deleted
+        ram_resource_share.wait_until_associated(
+            resource_share_arn, "RESOURCE", arns[0], associated=False,
+        )
+
+        _, deleted = k8s.delete_custom_resource(
+            ref,
+            period_length=DELETE_WAIT_AFTER_SECONDS,
+        )
+        assert deleted
+
+        _, deleted = k8s.delete_custom_resource(
+            unselected,
+            period_length=DELETE_WAIT_AFTER_SECONDS,
+        )
+        assert deleted